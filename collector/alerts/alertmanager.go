@@ -0,0 +1,158 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// path of the Alertmanager v2 API receiving the alerts
+const alertmanagerAlertsPath = "/api/v2/alerts"
+
+// alertname label of the CCMS alerts in Alertmanager
+const alertmanagerAlertName = "SAPCCMSAlert"
+
+// posts the open CCMS alerts to Alertmanager, see https://github.com/prometheus/alertmanager/blob/main/api/v2/openapi.yaml
+type alertmanagerSink struct {
+	url            string
+	client         *http.Client
+	resendInterval time.Duration
+	// time the open alerts of an instance were last sent, by instance endpoint
+	lastSent map[string]time.Time
+	// the open alerts last sent, by instance endpoint
+	sent map[string]map[alertKey]bool
+	// the ended alerts of a failed post, by instance endpoint, sent again with the next post
+	pending map[string][]sapcontrol.Alert
+}
+
+// postableAlert of the Alertmanager v2 API
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// the posts are sent with the alert states locked, timeout bounds them rather than the scrape context
+func newAlertmanagerSink(url string, resendInterval time.Duration, timeout time.Duration) *alertmanagerSink {
+	if resendInterval <= 0 {
+		resendInterval = time.Minute
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &alertmanagerSink{
+		url:            strings.TrimSuffix(url, "/") + alertmanagerAlertsPath,
+		client:         &http.Client{Timeout: timeout},
+		resendInterval: resendInterval,
+		lastSent:       make(map[string]time.Time),
+		sent:           make(map[string]map[alertKey]bool),
+		pending:        make(map[string][]sapcontrol.Alert),
+	}
+}
+
+// sends the ended alerts of an instance as resolved, and the open ones when one was raised or alertmanager_resend_interval passed.
+// The open alerts are sent with endsAt three resend intervals ahead, so that Alertmanager resolves them
+// by itself when the exporter stops sending them. The ended alerts of a failed post are kept and sent with the next one.
+func (s *alertmanagerSink) send(endpoint string, commonLabels []string, open []sapcontrol.Alert, ended []sapcontrol.Alert, timeLocation *time.Location) error {
+	now := time.Now()
+
+	sent := make(map[alertKey]bool)
+	openColors := make(map[alertKey]sapcontrol.STATECOLOR)
+	raised := false
+	for _, alert := range open {
+		key := alertKey{alert.Tid, alert.Aid}
+		sent[key] = true
+		openColors[key] = alert.Value
+		raised = raised || !s.sent[endpoint][key]
+	}
+	// a pending alert open again with the same color is no longer ended
+	retried := []sapcontrol.Alert{}
+	for _, alert := range s.pending[endpoint] {
+		if color, open := openColors[alertKey{alert.Tid, alert.Aid}]; !open || color != alert.Value {
+			retried = append(retried, alert)
+		}
+	}
+	ended = append(retried, ended...)
+	delete(s.pending, endpoint)
+	if !raised && len(ended) == 0 && now.Sub(s.lastSent[endpoint]) < s.resendInterval {
+		return nil
+	}
+
+	alerts := []alertmanagerAlert{}
+	for _, alert := range ended {
+		alerts = append(alerts, s.makeAlert(alert, commonLabels, now, timeLocation))
+	}
+	for _, alert := range open {
+		alerts = append(alerts, s.makeAlert(alert, commonLabels, now.Add(3*s.resendInterval), timeLocation))
+	}
+	if len(alerts) == 0 {
+		s.lastSent[endpoint] = now
+		s.sent[endpoint] = sent
+		return nil
+	}
+
+	if err := s.post(alerts); err != nil {
+		if len(ended) > 0 {
+			s.pending[endpoint] = ended
+		}
+		return err
+	}
+	s.lastSent[endpoint] = now
+	s.sent[endpoint] = sent
+	return nil
+}
+
+func (s *alertmanagerSink) post(alerts []alertmanagerAlert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return errors.Wrap(err, "alertmanager")
+	}
+	request, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "alertmanager")
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "alertmanager")
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("alertmanager: url=%s, status=%s", s.url, response.Status)
+	}
+	return nil
+}
+
+func (s *alertmanagerSink) makeAlert(alert sapcontrol.Alert, commonLabels []string, endsAt time.Time, timeLocation *time.Location) alertmanagerAlert {
+	severity, _ := sapcontrol.StateColorToLevel(alert.Value)
+	amAlert := alertmanagerAlert{
+		Labels: map[string]string{
+			"alertname":         alertmanagerAlertName,
+			"alert_id":          alert.Tid + "/" + alert.Aid,
+			"object":            alert.Object,
+			"attribute":         alert.Attribute,
+			"severity":          severity,
+			"instance_name":     commonLabels[0],
+			"instance_number":   commonLabels[1],
+			"SID":               commonLabels[2],
+			"instance_hostname": commonLabels[3],
+		},
+		Annotations: map[string]string{
+			"description": alert.Description,
+		},
+		EndsAt: endsAt,
+	}
+	if startsAt, err := sapcontrol.ParseSAPTime(alert.ATime, timeLocation); err == nil {
+		amAlert.StartsAt = startsAt
+	}
+	return amAlert
+}
@@ -2,8 +2,10 @@ package alerts
 
 import (
 	"context"
+	"os"
+	"regexp"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -11,7 +13,6 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/vgrusdev/promtail-client/promtail"
 	"github.com/vgrusdev/sap_system_exporter/collector"
 	"github.com/vgrusdev/sap_system_exporter/internal/config"
 	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
@@ -21,6 +22,15 @@ type alertsCollector struct {
 	collector.DefaultCollector
 	webService sapcontrol.WebService
 	logger     *config.Logger
+	// alert_tree_node_patterns, the alert tree nodes to export
+	treePatterns []*regexp.Regexp
+	mu           sync.Mutex
+	// open alerts at the last scrape, by instance endpoint
+	alertStates map[string]*instanceAlerts
+	// alert_state_file, empty if the open alerts are not kept across restarts
+	stateFile string
+	// nil if alertmanager_url is not set
+	alertmanager *alertmanagerSink
 }
 
 func NewCollector(webService sapcontrol.WebService) (*alertsCollector, error) {
@@ -29,8 +39,39 @@ func NewCollector(webService sapcontrol.WebService) (*alertsCollector, error) {
 		collector.NewDefaultCollector("alerts"),
 		webService,
 		config.NewLogger("alerts"),
+		[]*regexp.Regexp{},
+		sync.Mutex{},
+		make(map[string]*instanceAlerts),
+		"",
+		nil,
+	}
+	v := webService.GetMyClient().GetMyConfig().Viper
+	c.logger.SetLevel(v.GetString("log_level"))
+
+	if url := v.GetString("alertmanager_url"); url != "" {
+		c.alertmanager = newAlertmanagerSink(url, v.GetDuration("alertmanager_resend_interval"), v.GetDuration("alertmanager_http_timeout"))
+	}
+
+	c.stateFile = v.GetString("alert_state_file")
+	if c.stateFile != "" {
+		if v.GetBool("alert_state_reset") {
+			if err := os.Remove(c.stateFile); err != nil && !os.IsNotExist(err) {
+				return nil, errors.Wrap(err, "alert_state_reset")
+			}
+			c.logger.Infof("Alert state file %s reset", c.stateFile)
+		} else if err := c.loadAlertState(c.stateFile); err != nil {
+			// a broken state file only costs duplicated Loki entries, it must not stop the exporter
+			c.logger.Warnf("%s, starting without the alert state", err)
+		}
+	}
+
+	for _, p := range v.GetStringSlice("alert_tree_node_patterns") {
+		pattern, err := regexp.Compile(p)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid alert_tree_node_patterns value")
+		}
+		c.treePatterns = append(c.treePatterns, pattern)
 	}
-	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
 
 	//c.SetDescriptor("Alert", "SAP System open Alerts", []string{"instance_name", "instance_number", "SID", "instance_hostname", "Object", "Attribute", "Description", "ATime", "Tid", "Aid"})
 	//c.SetDescriptor("Alert", "SAP System open Alerts", []string{"instance_name", "instance_number", "SID", "instance_hostname", "Object", "Attribute", "Description", "ATime", "Aluniqnum"})
@@ -38,6 +79,11 @@ func NewCollector(webService sapcontrol.WebService) (*alertsCollector, error) {
 	//c.SetDescriptor("Alert", "SAP System open Alerts", []string{"Object", "Attribute", "Message", "ATime", "Level", "instance_name", "instance_number", "SID", "instance_hostname"})
 	c.SetDescriptor("Alert", "SAP System open Alerts", []string{"Object", "Attribute", "Message", "ATime", "State",
 		"instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("open", "Open alerts of the instance by level",
+		[]string{"level", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("raised_total", "Alerts raised on the instance since the exporter start, by level when raised",
+		[]string{"level", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.setTreeDescriptors()
 
 	return c, nil
 }
@@ -51,10 +97,13 @@ func (c *alertsCollector) Collect(ch chan<- prometheus.Metric) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	err := c.recordAlerts(ctx, ch)
-	if err != nil {
+	errs := collector.RecordConcurrently(ctx, []func(ctx context.Context, ch chan<- prometheus.Metric) error{
+		c.recordAlerts,
+		c.recordAlertTree,
+	}, ch)
+
+	for _, err := range errs {
 		log.Errorf("Alerts Collector: %s", err)
-		return
 	}
 }
 
@@ -66,17 +115,6 @@ type current_alert struct {
 	ATime       string
 }
 
-func labelSetFromArrays(keys []string, values []string) (map[string]string, error) {
-	m := make(map[string]string)
-	if len(keys) != len(values) {
-		return m, errors.New("labelSetFromArrays: Arrays should be the same length")
-	}
-	for i, key := range keys {
-		m[key] = values[i]
-	}
-	return m, nil
-}
-
 func (c *alertsCollector) recordAlerts(ctx context.Context, ch chan<- prometheus.Metric) error {
 	// VG ++    loop on instances
 	log := c.logger
@@ -104,25 +142,19 @@ func (c *alertsCollector) recordAlerts(ctx context.Context, ch chan<- prometheus
 	} else {
 		log.Debug("Will not send Alerts to Prom")
 	}
-	const timeFormat = "2006 01 02 15:04:05"
-	var labelNames []string
 	var timeLocation *time.Location
 
 	loki_client := c.webService.GetLokiClient()
-
 	if loki_client != nil {
-		promDesc := c.GetDescriptor("Alert")
-		promDescString := promDesc.String()
-
-		log.Debugf("promDescriptorString = %s", promDescString)
-
-		_, after, _ := strings.Cut(promDescString, "variableLabels: {")
-		after = strings.TrimSuffix(after, "}}")
-		labelNames = strings.Split(after, ",")
-		//log.Debugf("alerts.go: Labels: %s\n", labelNames)
 		timeLocation = loki_client.GetLocation()
+	} else {
+		timeLocation = c.webService.GetMyClient().GetTimeLocation()
 	}
 
+	// scrapes may overlap, the alert states must only be moved by one of them at a time
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	for _, instance := range instanceInfo {
 
 		url := instance.Endpoint
@@ -141,84 +173,78 @@ func (c *alertsCollector) recordAlerts(ctx context.Context, ch chan<- prometheus
 			continue
 		}
 
-		alert_item_list := []current_alert{}
+		if send_to_prom {
+			alert_item_list := []current_alert{}
 
-		for _, alert := range alertList.Alerts {
+			for _, alert := range alertList.Alerts {
 
-			alert_item := current_alert{
-				Object:      alert.Object,
-				Attribute:   alert.Attribute,
-				Value:       alert.Value,
-				Description: alert.Description,
-				ATime:       alert.ATime,
+				alert_item := current_alert{
+					Object:      alert.Object,
+					Attribute:   alert.Attribute,
+					Value:       alert.Value,
+					Description: alert.Description,
+					ATime:       alert.ATime,
+				}
+				alert_item_list = append(alert_item_list, alert_item)
 			}
-			alert_item_list = append(alert_item_list, alert_item)
-		}
-		if send_to_prom {
 			// Remove duplicates for Prometheus
 			log.Debugf("Alerts in the list BEFORE remove duplicates: %d", len(alert_item_list))
 			alert_item_list = sapcontrol.RemoveDuplicate(alert_item_list)
 			log.Debugf("Alerts in the list AFTER remove duplicates: %d", len(alert_item_list))
-		} else {
-			log.Debugf("Alerts in the list: %d", len(alert_item_list))
-		}
-		num_sent_to_loki := 0
-		for _, alert_item := range alert_item_list {
 
-			state, err := sapcontrol.StateColorToFloat(alert_item.Value)
-			if err != nil {
-				log.Warnf("SrecordAlerts: Alert State conversion %v: %s", alert_item.Value, err)
-				continue
-			}
-			labels := append([]string{alert_item.Object,
-				alert_item.Attribute,
-				alert_item.Description,
-				alert_item.ATime,
-				string(alert_item.Value)},
-				commonLabels...)
-
-			// Response to Prometheus request (may be to setup IF...  TODO )
-			// need to check what will be if I will not send anything to prom.
-			// or should I send something small....
-			if send_to_prom {
-				ch <- c.MakeGaugeMetric("Alert", state, labels...)
-			}
+			for _, alert_item := range alert_item_list {
 
-			// Push to LOKI ====================================================================
-			if loki_client != nil {
-				labelSet, err := labelSetFromArrays(labelNames, labels)
+				state, err := sapcontrol.StateColorToFloat(alert_item.Value)
 				if err != nil {
-					log.Warnf("Alert metrics LabelSet mismatch: %s", err)
+					log.Warnf("SrecordAlerts: Alert State conversion %v: %s", alert_item.Value, err)
 					continue
 				}
-				message := string(labelSet["Message"])
-				aTime := string(labelSet["ATime"])
+				labels := append([]string{alert_item.Object,
+					alert_item.Attribute,
+					alert_item.Description,
+					alert_item.ATime,
+					string(alert_item.Value)},
+					commonLabels...)
 
-				t, err := time.ParseInLocation(timeFormat, aTime, timeLocation)
-				if err != nil {
-					log.Warnf("Alert ATime parsing: %s", err)
-					t = time.Now()
-				}
-				log.Debugf("aTime: %s, alert time: %v, time since: %v, sample_max_age: %v, timeLocation: %v", aTime, t, time.Since(t), samples_max_age, timeLocation)
-				if (samples_max_age >= 0) && (time.Since(t) > samples_max_age) {
-					log.Debugf("Alert entry too far behind, ts=%v", t)
-					continue
-				}
-				delete(labelSet, "Message")
-				delete(labelSet, "ATime")
-				labelSet["level"], _ = sapcontrol.StateColorToLevel(alert_item.Value)
-
-				sInputEntry := promtail.SingleEntry{
-					Labels: labelSet,
-					Ts:     t,
-					Line:   message,
-				}
-				loki_client.Single() <- &sInputEntry
-				num_sent_to_loki += 1
-			} // if loki_client != nil
-		} // for _, alert_item := range alert_item_list
-		log.Debugf("Alerts sent to loki: %d", num_sent_to_loki)
+				ch <- c.MakeGaugeMetric("Alert", state, labels...)
+			}
+		}
+
+		// Push the alert changes to LOKI, and count the open and raised alerts
+		alertState, ended := c.trackAlerts(url, commonLabels, alertList.Alerts, loki_client, timeLocation, samples_max_age)
+
+		// Send the open alerts to Alertmanager
+		if c.alertmanager != nil {
+			open := []sapcontrol.Alert{}
+			for _, alert := range alertState.open {
+				open = append(open, alert)
+			}
+			if err := c.alertmanager.send(url, commonLabels, open, ended, timeLocation); err != nil {
+				log.Errorf("recordAlerts: %v", err)
+			}
+		}
+
+		open := make(map[string]float64)
+		for level := range alertState.raised {
+			open[level] = 0
+		}
+		for _, alert := range alertState.open {
+			level, _ := sapcontrol.StateColorToLevel(alert.Value)
+			open[level]++
+		}
+		for level, count := range open {
+			ch <- c.MakeGaugeMetric("open", count, append([]string{level}, commonLabels...)...)
+		}
+		for level, count := range alertState.raised {
+			ch <- c.MakeCounterMetric("raised_total", count, append([]string{level}, commonLabels...)...)
+		}
 	} // for _, instance := range instanceList.Instances
+
+	if c.stateFile != "" {
+		if err := c.saveAlertState(c.stateFile); err != nil {
+			log.Errorf("recordAlerts: %v", err)
+		}
+	}
 	log.Debug("recordAlerts success")
 	return nil
 }
@@ -0,0 +1,494 @@
+package alerts
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/promtail-client/promtail"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+// the entries pushed since the last call, by alert Object
+func receivedByObject(loki *fixtures.FakeLokiClient) map[string]*promtail.SingleEntry {
+	entries := make(map[string]*promtail.SingleEntry)
+	for _, entry := range loki.Received() {
+		entries[entry.Labels["Object"]] = entry
+	}
+	return entries
+}
+
+func newMockWebService(ctrl *gomock.Controller) *mock_sapcontrol.MockWebService {
+	return fixtures.NewMockWebService(ctrl, map[string]interface{}{"alert_samples_max_age": "-1s"})
+}
+
+// one dialog instance, the recorders not under test get no data
+func expectAlertInstances(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetAlerts(gomock.Any(), gomock.Any()).Return(&sapcontrol.GetAlertsResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().GetAlertTree(gomock.Any(), gomock.Any()).Return(&sapcontrol.GetAlertTreeResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().GetLokiClient().Return(nil).AnyTimes()
+}
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestNewCollectorInvalidTreePattern(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alert_tree_node_patterns", []string{"Dialog("})
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Error(t, err)
+}
+
+func TestAlertLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+
+	swap := sapcontrol.Alert{Object: "R3Services", Attribute: "Swap", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Swap space low", ATime: "2025 03 01 10:00:00", Tid: "T1", Aid: "A1"}
+	dump := sapcontrol.Alert{Object: "R3Abap", Attribute: "Shortdumps", Value: sapcontrol.STATECOLOR_RED, Description: "Short dumps", ATime: "2025 03 01 10:01:00", Tid: "T2", Aid: "A2"}
+	spool := sapcontrol.Alert{Object: "Spool", Attribute: "ErrorsInWpSPO", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Spool errors", ATime: "2025 03 01 10:05:00", Tid: "T3", Aid: "A3"}
+	swapRed := swap
+	swapRed.Value = sapcontrol.STATECOLOR_RED
+	gomock.InOrder(
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap, &dump},
+		}, nil),
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swapRed, &spool},
+		}, nil),
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swapRed, &spool},
+		}, nil),
+	)
+	expectAlertInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	testutil.CollectAndCount(collector)
+	entries := receivedByObject(loki)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "raised", entries["R3Services"].Labels["event"])
+	assert.Equal(t, "warning", entries["R3Services"].Labels["level"])
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), entries["R3Services"].Ts)
+	assert.Equal(t, "raised", entries["R3Abap"].Labels["event"])
+
+	expectedMetrics := `
+	# HELP sap_alerts_open Open alerts of the instance by level
+	# TYPE sap_alerts_open gauge
+	sap_alerts_open{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",level="error"} 1
+	sap_alerts_open{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",level="warning"} 1
+	# HELP sap_alerts_raised_total Alerts raised on the instance since the exporter start, by level when raised
+	# TYPE sap_alerts_raised_total counter
+	sap_alerts_raised_total{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",level="error"} 1
+	sap_alerts_raised_total{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",level="warning"} 2
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics), "sap_alerts_open", "sap_alerts_raised_total")
+	assert.NoError(t, err)
+
+	// the swap alert turned red, the short dump alert is resolved, the spool alert is new
+	entries = receivedByObject(loki)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "changed", entries["R3Services"].Labels["event"])
+	assert.Equal(t, "error", entries["R3Services"].Labels["level"])
+	assert.Equal(t, "resolved", entries["R3Abap"].Labels["event"])
+	assert.Equal(t, "info", entries["R3Abap"].Labels["level"])
+	assert.Equal(t, "raised", entries["Spool"].Labels["event"])
+
+	// nothing changed
+	testutil.CollectAndCount(collector)
+	assert.Len(t, receivedByObject(loki), 0)
+}
+
+func TestAlertStateAcrossRestarts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateFile := filepath.Join(t.TempDir(), "alerts.json")
+	swap := sapcontrol.Alert{Object: "R3Services", Attribute: "Swap", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Swap space low", ATime: "2025 03 01 10:00:00", Tid: "T1", Aid: "A1"}
+	dump := sapcontrol.Alert{Object: "R3Abap", Attribute: "Shortdumps", Value: sapcontrol.STATECOLOR_RED, Description: "Short dumps", ATime: "2025 03 01 10:01:00", Tid: "T2", Aid: "A2"}
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alert_state_file", stateFile)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+	gomock.InOrder(
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap, &dump},
+		}, nil),
+		// after the restart the short dump alert is resolved
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap},
+		}, nil),
+		// after the reset the swap alert is raised again
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap},
+		}, nil),
+	)
+	expectAlertInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	assert.Len(t, receivedByObject(loki), 2)
+
+	data, err := os.ReadFile(stateFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"version": 1`)
+	assert.Contains(t, string(data), `"last_forwarded": "2025-03-01T10:01:00Z"`)
+
+	restarted, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	testutil.CollectAndCount(restarted, "sap_alerts_open")
+	entries := receivedByObject(loki)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "resolved", entries["R3Abap"].Labels["event"])
+
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alert_state_reset", true)
+	reset, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	_, err = os.Stat(stateFile)
+	assert.True(t, os.IsNotExist(err))
+	testutil.CollectAndCount(reset, "sap_alerts_open")
+	entries = receivedByObject(loki)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "raised", entries["R3Services"].Labels["event"])
+}
+
+func TestAlertStateLastForwarded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the last run pushed the swap alert but stopped before saving it as open
+	stateFile := filepath.Join(t.TempDir(), "alerts.json")
+	err := os.WriteFile(stateFile, []byte(`{"version": 1, "instances": {"http://sapha1pas:50113": {"last_forwarded": "2025-03-01T10:00:00Z", "alerts": []}}}`), 0o600)
+	assert.NoError(t, err)
+	swap := sapcontrol.Alert{Object: "R3Services", Attribute: "Swap", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Swap space low", ATime: "2025 03 01 10:00:00", Tid: "T1", Aid: "A1"}
+	dump := sapcontrol.Alert{Object: "R3Abap", Attribute: "Shortdumps", Value: sapcontrol.STATECOLOR_RED, Description: "Short dumps", ATime: "2025 03 01 10:01:00", Tid: "T2", Aid: "A2"}
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alert_state_file", stateFile)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+	mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+		Alerts: []*sapcontrol.Alert{&swap, &dump},
+	}, nil)
+	expectAlertInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	entries := receivedByObject(loki)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "raised", entries["R3Abap"].Labels["event"])
+}
+
+// stands in for the Alertmanager v2 API, keeping the posted alerts
+type fakeAlertmanager struct {
+	mu    sync.Mutex
+	posts [][]alertmanagerAlert
+	// answers 503 to the posts while set
+	down bool
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/api/v2/alerts" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	alerts := []alertmanagerAlert{}
+	if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	f.posts = append(f.posts, alerts)
+}
+
+func (f *fakeAlertmanager) setDown(down bool) {
+	f.mu.Lock()
+	f.down = down
+	f.mu.Unlock()
+}
+
+// returns the posts received since the last call, with the alerts by attribute and severity
+func (f *fakeAlertmanager) received() []map[string]alertmanagerAlert {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	posts := []map[string]alertmanagerAlert{}
+	for _, post := range f.posts {
+		alerts := make(map[string]alertmanagerAlert)
+		for _, alert := range post {
+			alerts[alert.Labels["attribute"]+"/"+alert.Labels["severity"]] = alert
+		}
+		posts = append(posts, alerts)
+	}
+	f.posts = nil
+	return posts
+}
+
+func TestAlertmanagerSink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertmanager := &fakeAlertmanager{}
+	server := httptest.NewServer(alertmanager)
+	defer server.Close()
+
+	swap := sapcontrol.Alert{Object: "R3Services", Attribute: "Swap", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Swap space low", ATime: "2025 03 01 10:00:00", Tid: "T1", Aid: "A1"}
+	dump := sapcontrol.Alert{Object: "R3Abap", Attribute: "Shortdumps", Value: sapcontrol.STATECOLOR_RED, Description: "Short dumps", ATime: "2025 03 01 10:01:00", Tid: "T2", Aid: "A2"}
+	swapRed := swap
+	swapRed.Value = sapcontrol.STATECOLOR_RED
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alertmanager_url", server.URL+"/")
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alertmanager_resend_interval", "1h")
+	gomock.InOrder(
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap, &dump},
+		}, nil),
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swapRed},
+		}, nil),
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swapRed},
+		}, nil),
+	)
+	expectAlertInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	posts := alertmanager.received()
+	assert.Len(t, posts, 1)
+	assert.Len(t, posts[0], 2)
+	alert := posts[0]["Swap/warning"]
+	assert.Equal(t, map[string]string{
+		"alertname":         "SAPCCMSAlert",
+		"alert_id":          "T1/A1",
+		"object":            "R3Services",
+		"attribute":         "Swap",
+		"severity":          "warning",
+		"instance_name":     "D01",
+		"instance_number":   "1",
+		"SID":               "HA1",
+		"instance_hostname": "sapha1pas",
+	}, alert.Labels)
+	assert.Equal(t, "Swap space low", alert.Annotations["description"])
+	assert.True(t, alert.StartsAt.Equal(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)))
+	assert.True(t, alert.EndsAt.After(start.Add(3*time.Hour-time.Minute)))
+	assert.Equal(t, "error", posts[0]["Shortdumps/error"].Labels["severity"])
+
+	// the swap alert turned red and the short dump alert is resolved, the ended ones are sent at once
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	posts = alertmanager.received()
+	assert.Len(t, posts, 1)
+	assert.Len(t, posts[0], 3)
+	assert.True(t, posts[0]["Shortdumps/error"].EndsAt.Before(time.Now().Add(time.Second)))
+	assert.True(t, posts[0]["Swap/warning"].EndsAt.Before(time.Now().Add(time.Second)))
+	assert.True(t, posts[0]["Swap/error"].EndsAt.After(time.Now().Add(time.Hour)))
+
+	// nothing changed within alertmanager_resend_interval
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	assert.Len(t, alertmanager.received(), 0)
+}
+
+func TestAlertmanagerEndedAlertsRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertmanager := &fakeAlertmanager{}
+	server := httptest.NewServer(alertmanager)
+	defer server.Close()
+
+	swap := sapcontrol.Alert{Object: "R3Services", Attribute: "Swap", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Swap space low", ATime: "2025 03 01 10:00:00", Tid: "T1", Aid: "A1"}
+	dump := sapcontrol.Alert{Object: "R3Abap", Attribute: "Shortdumps", Value: sapcontrol.STATECOLOR_RED, Description: "Short dumps", ATime: "2025 03 01 10:01:00", Tid: "T2", Aid: "A2"}
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alertmanager_url", server.URL)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alertmanager_resend_interval", "1h")
+	gomock.InOrder(
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap, &dump},
+		}, nil),
+		mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+			Alerts: []*sapcontrol.Alert{&swap},
+		}, nil).Times(2),
+	)
+	expectAlertInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	assert.Len(t, alertmanager.received(), 1)
+
+	// the short dump alert is resolved while Alertmanager is down
+	alertmanager.setDown(true)
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	assert.Len(t, alertmanager.received(), 0)
+
+	// nothing changed, the resolved alert is sent again
+	alertmanager.setDown(false)
+	testutil.CollectAndCount(collector, "sap_alerts_open")
+	posts := alertmanager.received()
+	assert.Len(t, posts, 1)
+	assert.Len(t, posts[0], 2)
+	assert.True(t, posts[0]["Shortdumps/error"].EndsAt.Before(time.Now().Add(time.Second)))
+	assert.True(t, posts[0]["Swap/warning"].EndsAt.After(time.Now().Add(time.Hour)))
+}
+
+func TestAlertmanagerTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Alertmanager hangs longer than alertmanager_http_timeout
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	swap := sapcontrol.Alert{Object: "R3Services", Attribute: "Swap", Value: sapcontrol.STATECOLOR_YELLOW, Description: "Swap space low", ATime: "2025 03 01 10:00:00", Tid: "T1", Aid: "A1"}
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alertmanager_url", server.URL)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alertmanager_http_timeout", "50ms")
+	mockWebService.EXPECT().GetAlerts(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertsResponse{
+		Alerts: []*sapcontrol.Alert{&swap},
+	}, nil)
+	expectAlertInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "sap_alerts_open"))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestAlertStateOtherVersionIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stateFile := filepath.Join(t.TempDir(), "alerts.json")
+	err := os.WriteFile(stateFile, []byte(`{"version": 2, "instances": {"http://sapha1pas:50113": {"alerts": [{"Tid": "T1", "Aid": "A1"}]}}}`), 0600)
+	assert.NoError(t, err)
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alert_state_file", stateFile)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	assert.Empty(t, collector.alertStates)
+}
+
+func TestNodePaths(t *testing.T) {
+	tree := []*sapcontrol.AlertNode{
+		{Name: "HA1", Parent: -1},
+		{Name: "R3Services", Parent: 0},
+		{Name: "Dialog", Parent: 1},
+		{Name: "ResponseTimeDialog", Parent: 2},
+		{Name: "Broken", Parent: 42},
+	}
+	assert.Equal(t, []string{"HA1", "HA1/R3Services", "HA1/R3Services/Dialog", "HA1/R3Services/Dialog/ResponseTimeDialog", "Broken"}, nodePaths(tree))
+}
+
+func TestParseNodeValue(t *testing.T) {
+	tests := []struct {
+		description string
+		value       float64
+		ok          bool
+	}{
+		{"Dialog Response Time 850 msec", 850, true},
+		{"97.5 %", 97.5, true},
+		{"Free space 12.5 GB", 12.5, true},
+		{"Users 12", 12, true},
+		{"-3 s", -3, true},
+		{"Server is running", 0, false},
+		{"R3Services", 0, false},
+		{"Work process 2 is running", 0, false},
+		{"Instance D01 850 msec", 850, true},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		value, ok := parseNodeValue(test.description)
+		assert.Equal(t, test.ok, ok, test.description)
+		assert.Equal(t, test.value, value, test.description)
+	}
+}
+
+func TestAlertTreeMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("alert_tree_node_patterns", []string{"/Dialog/ResponseTimeDialog$"})
+	mockWebService.EXPECT().GetAlertTree(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetAlertTreeResponse{
+		Tree: []*sapcontrol.AlertNode{
+			{Name: "HA1", Parent: -1, ActualValue: sapcontrol.STATECOLOR_GREEN, HighAlertValue: sapcontrol.STATECOLOR_YELLOW},
+			{Name: "R3Services", Parent: 0, ActualValue: sapcontrol.STATECOLOR_GREEN, HighAlertValue: sapcontrol.STATECOLOR_YELLOW},
+			{Name: "Dialog", Parent: 1, ActualValue: sapcontrol.STATECOLOR_GREEN, HighAlertValue: sapcontrol.STATECOLOR_YELLOW},
+			{Name: "ResponseTimeDialog", Parent: 2, ActualValue: sapcontrol.STATECOLOR_GREEN, HighAlertValue: sapcontrol.STATECOLOR_YELLOW, Description: "850 msec"},
+			// same path as the node above, not exported
+			{Name: "ResponseTimeDialog", Parent: 2, ActualValue: sapcontrol.STATECOLOR_RED, HighAlertValue: sapcontrol.STATECOLOR_RED, Description: "9000 msec"},
+		},
+	}, nil)
+	expectAlertInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_alerts_tree_node_high_alert_state Highest open alert state of the CCMS alert tree node, following the SAP state colors
+	# TYPE sap_alerts_tree_node_high_alert_state gauge
+	sap_alerts_tree_node_high_alert_state{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",node_path="HA1/R3Services/Dialog/ResponseTimeDialog"} 3
+	# HELP sap_alerts_tree_node_state Current state of the CCMS alert tree node, following the SAP state colors
+	# TYPE sap_alerts_tree_node_state gauge
+	sap_alerts_tree_node_state{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",node_path="HA1/R3Services/Dialog/ResponseTimeDialog"} 2
+	# HELP sap_alerts_tree_node_value Value of the CCMS alert tree node, the first number of the node description
+	# TYPE sap_alerts_tree_node_value gauge
+	sap_alerts_tree_node_value{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",node_path="HA1/R3Services/Dialog/ResponseTimeDialog"} 850
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_alerts_tree_node_state", "sap_alerts_tree_node_high_alert_state", "sap_alerts_tree_node_value")
+	assert.NoError(t, err)
+}
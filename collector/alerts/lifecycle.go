@@ -0,0 +1,140 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/vgrusdev/promtail-client/promtail"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// identifies an alert of an instance
+type alertKey struct {
+	tid string
+	aid string
+}
+
+// open alerts and raised alert counts of an instance
+type instanceAlerts struct {
+	open map[alertKey]sapcontrol.Alert
+	// by level of the alert when raised
+	raised map[string]float64
+	// timestamp of the last entry pushed to Loki
+	lastForwarded time.Time
+	// lastForwarded restored from the alert_state_file, the raised and changed alerts up to it
+	// were already pushed by the last run, zero after the first scrape
+	resumedAfter time.Time
+	// open alerts changed since the alert_state_file was written
+	changed bool
+}
+
+// alert lifecycle events pushed to Loki
+const (
+	alertRaised   = "raised"
+	alertChanged  = "changed"
+	alertResolved = "resolved"
+)
+
+// compares the open alerts of the instance with the previous scrape, and pushes the raised, changed and resolved ones to Loki.
+// Returns the instance alerts, and the ended alerts: the resolved ones and the previous color of the changed ones.
+// Must be called with c.mu locked.
+func (c *alertsCollector) trackAlerts(endpoint string, commonLabels []string, alerts []*sapcontrol.Alert, loki_client promtail.Client, timeLocation *time.Location, samples_max_age time.Duration) (*instanceAlerts, []sapcontrol.Alert) {
+	log := c.logger
+
+	state, known := c.alertStates[endpoint]
+	if !known {
+		state = &instanceAlerts{
+			open:   make(map[alertKey]sapcontrol.Alert),
+			raised: make(map[string]float64),
+		}
+		c.alertStates[endpoint] = state
+	}
+
+	current := make(map[alertKey]sapcontrol.Alert)
+	for _, alert := range alerts {
+		current[alertKey{alert.Tid, alert.Aid}] = *alert
+	}
+
+	ended := []sapcontrol.Alert{}
+	num_sent_to_loki := 0
+	resumedAfter := state.resumedAfter
+	state.resumedAfter = time.Time{}
+	push := func(event string, alert sapcontrol.Alert) {
+		state.changed = true
+		if t, ok := c.pushAlert(loki_client, event, alert, commonLabels, timeLocation, samples_max_age, resumedAfter); ok {
+			num_sent_to_loki++
+			if t.After(state.lastForwarded) {
+				state.lastForwarded = t
+			}
+		}
+	}
+	for key, alert := range current {
+		previous, open := state.open[key]
+		switch {
+		case !open:
+			level, _ := sapcontrol.StateColorToLevel(alert.Value)
+			state.raised[level]++
+			push(alertRaised, alert)
+		case previous.Value != alert.Value:
+			ended = append(ended, previous)
+			push(alertChanged, alert)
+		}
+	}
+	for key, alert := range state.open {
+		if _, open := current[key]; !open {
+			ended = append(ended, alert)
+			push(alertResolved, alert)
+		}
+	}
+	state.open = current
+	log.Debugf("Alerts sent to loki: %d", num_sent_to_loki)
+
+	return state, ended
+}
+
+// pushes an alert event to Loki, the raised and changed events are timestamped with the alert time,
+// and skipped when older than alert_samples_max_age or not after resumedAfter, as the last run pushed them already.
+// Returns the entry timestamp and whether it was pushed.
+func (c *alertsCollector) pushAlert(loki_client promtail.Client, event string, alert sapcontrol.Alert, commonLabels []string, timeLocation *time.Location, samples_max_age time.Duration, resumedAfter time.Time) (time.Time, bool) {
+	log := c.logger
+
+	if loki_client == nil {
+		return time.Time{}, false
+	}
+
+	t := time.Now()
+	level := "info"
+	if event != alertResolved {
+		aTime, err := sapcontrol.ParseSAPTime(alert.ATime, timeLocation)
+		if err != nil {
+			log.Warnf("Alert ATime parsing: %s", err)
+		} else {
+			t = aTime
+		}
+		if (samples_max_age >= 0) && (time.Since(t) > samples_max_age) {
+			log.Debugf("Alert entry too far behind, ts=%v", t)
+			return t, false
+		}
+		if err == nil && !t.After(resumedAfter) {
+			log.Debugf("Alert entry forwarded by the last run, ts=%v", t)
+			return t, false
+		}
+		level, _ = sapcontrol.StateColorToLevel(alert.Value)
+	}
+
+	loki_client.Single() <- &promtail.SingleEntry{
+		Labels: map[string]string{
+			"Object":            alert.Object,
+			"Attribute":         alert.Attribute,
+			"State":             string(alert.Value),
+			"event":             event,
+			"level":             level,
+			"instance_name":     commonLabels[0],
+			"instance_number":   commonLabels[1],
+			"SID":               commonLabels[2],
+			"instance_hostname": commonLabels[3],
+		},
+		Ts:   t,
+		Line: alert.Description,
+	}
+	return t, true
+}
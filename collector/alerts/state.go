@@ -0,0 +1,118 @@
+package alerts
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// version of the alert_state_file format, a file of another version is ignored
+const alertStateVersion = 1
+
+// content of the alert_state_file
+type alertStateFile struct {
+	Version   int                              `json:"version"`
+	Instances map[string]*instanceAlertsRecord `json:"instances"`
+}
+
+// open alerts of an instance, by instance endpoint in the alert_state_file
+type instanceAlertsRecord struct {
+	LastForwarded time.Time          `json:"last_forwarded"`
+	Alerts        []sapcontrol.Alert `json:"alerts"`
+}
+
+// restores the open alerts of the last run from the alert_state_file, so that they are not raised again after a restart.
+// The alerts the last run pushed to Loki after its last save are skipped by their time, up to last_forwarded.
+func (c *alertsCollector) loadAlertState(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "loadAlertState")
+	}
+
+	stateFile := alertStateFile{}
+	if err := json.Unmarshal(data, &stateFile); err != nil {
+		return errors.Wrapf(err, "loadAlertState: %s", path)
+	}
+	if stateFile.Version != alertStateVersion {
+		c.logger.Warnf("loadAlertState: %s has version %d instead of %d, ignored", path, stateFile.Version, alertStateVersion)
+		return nil
+	}
+
+	for endpoint, record := range stateFile.Instances {
+		state := &instanceAlerts{
+			open:          make(map[alertKey]sapcontrol.Alert),
+			raised:        make(map[string]float64),
+			lastForwarded: record.LastForwarded,
+			resumedAfter:  record.LastForwarded,
+		}
+		for _, alert := range record.Alerts {
+			state.open[alertKey{alert.Tid, alert.Aid}] = alert
+		}
+		c.alertStates[endpoint] = state
+	}
+	c.logger.Infof("Alert state of %d instances restored from %s", len(stateFile.Instances), path)
+	return nil
+}
+
+// writes the open alerts to the alert_state_file if they changed, through a temporary file renamed over the old one.
+// Must be called with c.mu locked.
+func (c *alertsCollector) saveAlertState(path string) error {
+	changed := false
+	stateFile := alertStateFile{
+		Version:   alertStateVersion,
+		Instances: make(map[string]*instanceAlertsRecord),
+	}
+	for endpoint, state := range c.alertStates {
+		changed = changed || state.changed
+		record := &instanceAlertsRecord{
+			LastForwarded: state.lastForwarded,
+			Alerts:        []sapcontrol.Alert{},
+		}
+		for _, alert := range state.open {
+			record.Alerts = append(record.Alerts, alert)
+		}
+		stateFile.Instances[endpoint] = record
+	}
+	if !changed {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(stateFile, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "saveAlertState")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return errors.Wrap(err, "saveAlertState")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "saveAlertState")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "saveAlertState")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "saveAlertState")
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrap(err, "saveAlertState")
+	}
+
+	for _, state := range c.alertStates {
+		state.changed = false
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package alerts
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// first number of the node description followed by a unit or ending it, e.g. "Dialog Response Time 850 msec", "97.5 %" or "Users 12",
+// the numbers in names like "R3Services" or "Work process 2 is running" are not values
+var nodeValueRegexp = regexp.MustCompile(`(?:^|\s)([-+]?\d+(?:\.\d+)?)\s*(?:%|(?i:msec|ms|sec|s|min|h|bytes|b|kb|mb|gb|tb|/s|/min|/h)\b|$)`)
+
+func (c *alertsCollector) setTreeDescriptors() {
+	nodeLabels := []string{"node_path", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("tree_node_state", "Current state of the CCMS alert tree node, following the SAP state colors", nodeLabels)
+	c.SetDescriptor("tree_node_high_alert_state", "Highest open alert state of the CCMS alert tree node, following the SAP state colors", nodeLabels)
+	c.SetDescriptor("tree_node_value", "Value of the CCMS alert tree node, the first number of the node description", nodeLabels)
+}
+
+func (c *alertsCollector) recordAlertTree(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+
+	// the tree holds thousands of nodes, nothing is exported without alert_tree_node_patterns
+	if len(c.treePatterns) == 0 {
+		return nil
+	}
+	log.Debug("recordAlertTree start")
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordAlertTree")
+	}
+
+	for _, instance := range instanceInfo {
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		alertTree, err := c.webService.GetAlertTree(ctx, instance.Endpoint)
+		if err != nil {
+			log.Warnf("GetAlertTree: %s", err)
+			continue
+		}
+
+		// sibling nodes may share a name, only the first node of a path is exported
+		seen := make(map[string]bool)
+		for i, path := range nodePaths(alertTree.Tree) {
+			if !c.matchTreePath(path) {
+				continue
+			}
+			if seen[path] {
+				log.Warnf("recordAlertTree: instance %s: duplicate node path %q, only the first node is exported", instance.Name, path)
+				continue
+			}
+			seen[path] = true
+			node := alertTree.Tree[i]
+			labels := append([]string{path}, commonLabels...)
+
+			if state, err := sapcontrol.StateColorToFloat(node.ActualValue); err == nil {
+				ch <- c.MakeGaugeMetric("tree_node_state", state, labels...)
+			}
+			if state, err := sapcontrol.StateColorToFloat(node.HighAlertValue); err == nil {
+				ch <- c.MakeGaugeMetric("tree_node_high_alert_state", state, labels...)
+			}
+			if value, ok := parseNodeValue(node.Description); ok {
+				ch <- c.MakeGaugeMetric("tree_node_value", value, labels...)
+			}
+		}
+	}
+	log.Debug("recordAlertTree success")
+	return nil
+}
+
+func (c *alertsCollector) matchTreePath(path string) bool {
+	for _, pattern := range c.treePatterns {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// the path of every node, the node names from the root joined with "/", the root nodes have a negative parent index
+func nodePaths(tree []*sapcontrol.AlertNode) []string {
+	paths := make([]string, len(tree))
+
+	var pathOf func(i int, depth int) string
+	pathOf = func(i int, depth int) string {
+		if paths[i] != "" {
+			return paths[i]
+		}
+		parent := int(tree[i].Parent)
+		// a broken parent index makes the node a root, rather than looping
+		if parent < 0 || parent >= len(tree) || parent == i || depth > len(tree) {
+			paths[i] = tree[i].Name
+		} else {
+			paths[i] = pathOf(parent, depth+1) + "/" + tree[i].Name
+		}
+		return paths[i]
+	}
+	for i := range tree {
+		pathOf(i, 0)
+	}
+	return paths
+}
+
+func parseNodeValue(description string) (float64, bool) {
+	match := nodeValueRegexp.FindStringSubmatch(strings.TrimSpace(description))
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
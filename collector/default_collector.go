@@ -54,11 +54,35 @@ func (c *DefaultCollector) MakeCounterMetric(name string, value float64, labelVa
 	return c.makeMetric(name, value, prometheus.CounterValue, labelValues...)
 }
 
+// `buckets` maps each upper bound to the cumulative count of observations, see HistogramBuckets
+func (c *DefaultCollector) MakeHistogramMetric(name string, count uint64, sum float64, buckets map[float64]uint64, labelValues ...string) prometheus.Metric {
+	desc := c.GetDescriptor(name)
+	return prometheus.MustNewConstHistogram(desc, count, sum, buckets, labelValues...)
+}
+
 func (c *DefaultCollector) makeMetric(name string, value float64, valueType prometheus.ValueType, labelValues ...string) prometheus.Metric {
 	desc := c.GetDescriptor(name)
 	return prometheus.MustNewConstMetric(desc, valueType, value, labelValues...)
 }
 
+// Turns a set of observed values into the count, sum and cumulative buckets expected by MakeHistogramMetric
+func HistogramBuckets(values []float64, upperBounds []float64) (uint64, float64, map[float64]uint64) {
+	buckets := make(map[float64]uint64, len(upperBounds))
+	var sum float64
+	for _, bound := range upperBounds {
+		buckets[bound] = 0
+	}
+	for _, value := range values {
+		sum += value
+		for _, bound := range upperBounds {
+			if value <= bound {
+				buckets[bound]++
+			}
+		}
+	}
+	return uint64(len(values)), sum, buckets
+}
+
 // Run multiple metric recording functions concurrently
 func RecordConcurrently(ctx context.Context, recorders []func(ctx context.Context, ch chan<- prometheus.Metric) error, ch chan<- prometheus.Metric) []error {
 	results := make(chan error, len(recorders))
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -22,18 +23,18 @@ func TestRecordConcurrently(t *testing.T) {
 	metrics := make(chan prometheus.Metric, 2)
 	metric1 := prometheus.NewGauge(prometheus.GaugeOpts{})
 	metric2 := prometheus.NewGauge(prometheus.GaugeOpts{})
-	recorder1 := func(ch chan<- prometheus.Metric) error {
+	recorder1 := func(ctx context.Context, ch chan<- prometheus.Metric) error {
 		// we make metric1 take longer so that we can assert that metric2 will come first
 		time.Sleep(time.Millisecond * 50)
 		ch <- metric1
 		return nil
 	}
-	recorder2 := func(ch chan<- prometheus.Metric) error {
+	recorder2 := func(ctx context.Context, ch chan<- prometheus.Metric) error {
 		ch <- metric2
 		return nil
 	}
 
-	errs := RecordConcurrently([]func(ch chan<- prometheus.Metric) error{recorder1, recorder2}, metrics)
+	errs := RecordConcurrently(context.Background(), []func(ctx context.Context, ch chan<- prometheus.Metric) error{recorder1, recorder2}, metrics)
 	assert.Len(t, errs, 0)
 	assert.Equal(t, metric2, <-metrics)
 	assert.Equal(t, metric1, <-metrics)
@@ -43,16 +44,16 @@ func TestRecordConcurrentlyErrors(t *testing.T) {
 	metrics := make(chan prometheus.Metric, 2)
 	metric2 := prometheus.NewGauge(prometheus.GaugeOpts{})
 	expectedError := errors.New("")
-	recorder1 := func(ch chan<- prometheus.Metric) error {
+	recorder1 := func(ctx context.Context, ch chan<- prometheus.Metric) error {
 		return expectedError
 	}
-	recorder2 := func(ch chan<- prometheus.Metric) error {
+	recorder2 := func(ctx context.Context, ch chan<- prometheus.Metric) error {
 		time.Sleep(time.Millisecond * 50)
 		ch <- metric2
 		return nil
 	}
 
-	errs := RecordConcurrently([]func(ch chan<- prometheus.Metric) error{recorder1, recorder2}, metrics)
+	errs := RecordConcurrently(context.Background(), []func(ctx context.Context, ch chan<- prometheus.Metric) error{recorder1, recorder2}, metrics)
 	assert.Len(t, errs, 1)
 	assert.Equal(t, expectedError, errs[0])
 	assert.Equal(t, metric2, <-metrics) // even if the first recorder returned an error, the second one should still run to completion
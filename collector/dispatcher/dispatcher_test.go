@@ -4,18 +4,29 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/golang/mock/gomock"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
 	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
 	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
 )
 
+// the ASCS instance runs the dispatcher
+func expectDispatcherInstance(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0}, Name: "ASCS", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+	}, nil)
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), "http://sapha1as:50013").Return([]sapcontrol.ProcessInfo{
+		{OSProcess: sapcontrol.OSProcess{Name: "disp+work", Dispstatus: sapcontrol.STATECOLOR_GREEN}},
+	}, nil)
+}
+
 func TestNewCollector(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
 
 	_, err := NewCollector(mockWebService)
 
@@ -26,8 +37,9 @@ func TestWorkProcessQueueStatsMetric(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-	mockWebService.EXPECT().GetQueueStatistic().Return(&sapcontrol.GetQueueStatisticResponse{
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectDispatcherInstance(mockWebService)
+	mockWebService.EXPECT().GetQueueStatistic(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.GetQueueStatisticResponse{
 		Queues: []*sapcontrol.TaskHandlerQueue{
 			{Type: "ABAP/NOWP", High: 3, Max: 14000, Writes: 249133, Reads: 249133},
 			{Type: "ABAP/DIA", High: 5, Max: 14000, Writes: 447173, Reads: 447173},
@@ -39,12 +51,6 @@ func TestWorkProcessQueueStatsMetric(t *testing.T) {
 			{Type: "ICM/Intern", High: 1, Max: 6000, Writes: 34877, Reads: 34877},
 		},
 	}, nil)
-	mockWebService.EXPECT().GetCurrentInstance().Return(&sapcontrol.CurrentSapInstance{
-		SID:      "HA1",
-		Number:   0,
-		Name:     "ASCS",
-		Hostname: "sapha1as",
-	}, nil)
 
 	expectedMetrics := `
 	# HELP sap_dispatcher_queue_high Work process peak queue length
@@ -111,9 +117,9 @@ func TestWorkProcessQueueStatsMetricWithEmptyData(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-	mockWebService.EXPECT().GetQueueStatistic().Return(&sapcontrol.GetQueueStatisticResponse{}, nil)
-	mockWebService.EXPECT().GetCurrentInstance().Return(&sapcontrol.CurrentSapInstance{}, nil)
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectDispatcherInstance(mockWebService)
+	mockWebService.EXPECT().GetQueueStatistic(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.GetQueueStatisticResponse{}, nil)
 
 	var err error
 	collector, err := NewCollector(mockWebService)
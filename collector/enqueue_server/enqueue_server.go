@@ -2,8 +2,10 @@ package enqueue_server
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	//log "github.com/sirupsen/logrus"
@@ -58,6 +60,12 @@ func NewCollector(webService sapcontrol.WebService) (*enqueueServerCollector, er
 	c.SetDescriptor("server_time", "Total time spent in lock operations by all processes in the enqueue server", []string{"instance_name", "instance_number", "SID", "instance_hostname"})
 	c.SetDescriptor("replication_state", "General state of lock server replication", []string{"instance_name", "instance_number", "SID", "instance_hostname"})
 
+	c.SetDescriptor("lock_table_locks_by_table", "Elementary locks in the lock table by table, top-N", []string{"table", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("lock_table_locks_by_mode", "Elementary locks in the lock table by lock mode, top-N", []string{"mode", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("lock_table_locks_by_client", "Elementary locks in the lock table by client, top-N", []string{"client", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("lock_table_locks_by_transaction", "Elementary locks in the lock table by transaction code, top-N", []string{"transaction", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("lock_table_oldest_lock_age_seconds", "Age of the oldest lock owner in the lock table", []string{"instance_name", "instance_number", "SID", "instance_hostname"})
+
 	return c, nil
 }
 
@@ -74,6 +82,27 @@ func (c *enqueueServerCollector) Collect(ch chan<- prometheus.Metric) {
 	if err != nil {
 		log.Errorf("Enqueue Server Collector: %s", err)
 	}
+
+	if v.GetBool("enqueue_lock_table") {
+		err = c.recordLockTable(ctx, ch)
+		if err != nil {
+			log.Errorf("Enqueue Server Collector: %s", err)
+		}
+	}
+}
+
+// returns true if the instance runs the enqueue server
+func (c *enqueueServerCollector) isEnqueueInstance(ctx context.Context, instance sapcontrol.InstanceInfo) (bool, error) {
+	processInfo, err := c.webService.GetCachedProcessList(ctx, instance.Endpoint)
+	if err != nil {
+		return false, err
+	}
+	for _, process := range processInfo {
+		if strings.Contains(process.Name, "msg_server") {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (c *enqueueServerCollector) recordEnqStats(ctx context.Context, ch chan<- prometheus.Metric) error {
@@ -91,22 +120,12 @@ func (c *enqueueServerCollector) recordEnqStats(ctx context.Context, ch chan<- p
 
 		url := instance.Endpoint
 
-		enqueueFound := false
-		processInfo, err := c.webService.GetCachedProcessList(ctx, url)
-		//processList, err := c.webService.GetProcessList(ctx, url)
+		enqueueFound, err := c.isEnqueueInstance(ctx, instance)
 		if err != nil {
 			log.Errorf("recordEnqStats: %v", err)
 			continue
 			//return errors.Wrap(err, "recordEnqStats")
 		}
-
-		//for _, process := range processList.Processes {
-		for _, process := range processInfo {
-			if strings.Contains(process.Name, "msg_server") {
-				enqueueFound = true
-				break
-			}
-		}
 		// if we found msg_server on process name we collect the Enqueue Server stats
 		if enqueueFound != true {
 			continue
@@ -186,3 +205,107 @@ func (c *enqueueServerCollector) recordEnqStats(ctx context.Context, ch chan<- p
 	}
 	return nil
 }
+
+func (c *enqueueServerCollector) recordLockTable(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordLockTable collecting")
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordLockTable")
+	}
+
+	myClient := c.webService.GetMyClient()
+	topN := myClient.GetMyConfig().Viper.GetInt("enqueue_lock_table_top_n")
+	loc := myClient.GetTimeLocation()
+
+	for _, instance := range instanceInfo {
+
+		enqueueFound, err := c.isEnqueueInstance(ctx, instance)
+		if err != nil {
+			log.Errorf("recordLockTable: %v", err)
+			continue
+		}
+		if enqueueFound != true {
+			continue
+		}
+
+		lockTable, err := c.webService.EnqGetLockTable(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordLockTable: %v", err)
+			continue
+		}
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		byTable := make(map[string]int)
+		byMode := make(map[string]int)
+		byClient := make(map[string]int)
+		byTransaction := make(map[string]int)
+		var oldest time.Time
+		for _, lock := range lockTable.Locks {
+			byTable[lock.Lockname]++
+			byMode[lock.Lockmode]++
+			byClient[lock.Client]++
+			byTransaction[lock.Transaction]++
+
+			if t, err := ownerTime(lock.Owner, loc); err == nil {
+				if oldest.IsZero() || t.Before(oldest) {
+					oldest = t
+				}
+			} else {
+				log.Debugf("recordLockTable: %v", err)
+			}
+		}
+
+		for _, group := range []struct {
+			metric string
+			counts map[string]int
+		}{
+			{"lock_table_locks_by_table", byTable},
+			{"lock_table_locks_by_mode", byMode},
+			{"lock_table_locks_by_client", byClient},
+			{"lock_table_locks_by_transaction", byTransaction},
+		} {
+			for _, key := range topKeys(group.counts, topN) {
+				labels := append([]string{key}, commonLabels...)
+				ch <- c.MakeGaugeMetric(group.metric, float64(group.counts[key]), labels...)
+			}
+		}
+		if !oldest.IsZero() {
+			ch <- c.MakeGaugeMetric("lock_table_oldest_lock_age_seconds", time.Since(oldest).Seconds(), commonLabels...)
+		}
+	}
+	return nil
+}
+
+// the lock owner id starts with the owner creation timestamp, e.g. 20250305101520123456000...
+func ownerTime(owner string, loc *time.Location) (time.Time, error) {
+	if len(owner) < 14 {
+		return time.Time{}, errors.Errorf("lock owner %q has no timestamp", owner)
+	}
+	return sapcontrol.ParseSAPTime(owner[:14], loc)
+}
+
+// returns up to n keys with the highest counts, n <= 0 means all the keys
+func topKeys(counts map[string]int, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if n > 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
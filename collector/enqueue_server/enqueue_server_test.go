@@ -3,19 +3,36 @@ package enqueue_server
 import (
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/golang/mock/gomock"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
 	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
 	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
 )
 
+// the ASCS instance runs the enqueue server, the dialog instance does not
+func expectEnqueueInstances(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0, Features: "MESSAGESERVER|ENQUE"}, Name: "ASCS", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), "http://sapha1as:50013").Return([]sapcontrol.ProcessInfo{
+		{OSProcess: sapcontrol.OSProcess{Name: "msg_server"}},
+		{OSProcess: sapcontrol.OSProcess{Name: "enq_server"}},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), "http://sapha1pas:50113").Return([]sapcontrol.ProcessInfo{
+		{OSProcess: sapcontrol.OSProcess{Name: "disp+work"}},
+	}, nil).AnyTimes()
+}
+
 func TestNewCollector(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
 
 	_, err := NewCollector(mockWebService)
 
@@ -26,8 +43,9 @@ func TestEnqueueServerMetrics(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-	mockWebService.EXPECT().EnqGetStatistic().Return(&sapcontrol.EnqGetStatisticResponse{
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectEnqueueInstances(mockWebService)
+	mockWebService.EXPECT().EnqGetStatistic(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.EnqGetStatisticResponse{
 		OwnerNow:           1,
 		OwnerHigh:          2,
 		OwnerMax:           3,
@@ -56,12 +74,6 @@ func TestEnqueueServerMetrics(t *testing.T) {
 		ServerTime:         23,
 		ReplicationState:   sapcontrol.STATECOLOR_RED,
 	}, nil)
-	mockWebService.EXPECT().GetCurrentInstance().Return(&sapcontrol.CurrentSapInstance{
-		SID:      "HA1",
-		Number:   0,
-		Name:     "ASCS",
-		Hostname: "sapha1as",
-	}, nil)
 
 	expectedMetrics := `
 	# HELP sap_enqueue_server_arguments_high Peak number of lock arguments that have been stored simultaneously in the lock table
@@ -154,3 +166,105 @@ func TestEnqueueServerMetrics(t *testing.T) {
 	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics))
 	assert.NoError(t, err)
 }
+
+func TestLockTableMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, map[string]interface{}{
+		"enqueue_lock_table":       true,
+		"enqueue_lock_table_top_n": 2,
+	})
+	expectEnqueueInstances(mockWebService)
+	mockWebService.EXPECT().EnqGetStatistic(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.EnqGetStatisticResponse{}, nil).AnyTimes()
+	// the dialog instance is not asked for its lock table
+	mockWebService.EXPECT().EnqGetLockTable(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.EnqGetLockTableResponse{
+		Locks: []*sapcontrol.EnqLock{
+			{Lockname: "VBAK", Lockmode: "E", Client: "100", Transaction: "VA02", Owner: "20250305101520123456000sapha1pas"},
+			{Lockname: "VBAK", Lockmode: "E", Client: "100", Transaction: "VA02", Owner: "20250305101521000000000sapha1pas"},
+			{Lockname: "VBAP", Lockmode: "E", Client: "100", Transaction: "VA02", Owner: "20250305101522000000000sapha1pas"},
+			{Lockname: "MARA", Lockmode: "X", Client: "200", Transaction: "MM02", Owner: "broken"},
+		},
+	}, nil).AnyTimes()
+
+	expectedMetrics := `
+	# HELP sap_enqueue_server_lock_table_locks_by_client Elementary locks in the lock table by client, top-N
+	# TYPE sap_enqueue_server_lock_table_locks_by_client gauge
+	sap_enqueue_server_lock_table_locks_by_client{SID="HA1",client="100",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0"} 3
+	sap_enqueue_server_lock_table_locks_by_client{SID="HA1",client="200",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0"} 1
+	# HELP sap_enqueue_server_lock_table_locks_by_mode Elementary locks in the lock table by lock mode, top-N
+	# TYPE sap_enqueue_server_lock_table_locks_by_mode gauge
+	sap_enqueue_server_lock_table_locks_by_mode{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",mode="E"} 3
+	sap_enqueue_server_lock_table_locks_by_mode{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",mode="X"} 1
+	# HELP sap_enqueue_server_lock_table_locks_by_table Elementary locks in the lock table by table, top-N
+	# TYPE sap_enqueue_server_lock_table_locks_by_table gauge
+	sap_enqueue_server_lock_table_locks_by_table{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",table="VBAK"} 2
+	sap_enqueue_server_lock_table_locks_by_table{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",table="MARA"} 1
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_enqueue_server_lock_table_locks_by_client", "sap_enqueue_server_lock_table_locks_by_mode",
+		"sap_enqueue_server_lock_table_locks_by_table")
+	assert.NoError(t, err)
+	// the broken owner ID is skipped
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "sap_enqueue_server_lock_table_oldest_lock_age_seconds"))
+}
+
+func TestLockTableNotCollectedByDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectEnqueueInstances(mockWebService)
+	mockWebService.EXPECT().EnqGetStatistic(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.EnqGetStatisticResponse{}, nil)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, testutil.CollectAndCount(collector, "sap_enqueue_server_lock_table_locks_by_table"))
+}
+
+func TestOwnerTime(t *testing.T) {
+	for _, tc := range []struct {
+		owner    string
+		expected time.Time
+		err      bool
+	}{
+		{"20250305101520123456000sapha1pas", time.Date(2025, 3, 5, 10, 15, 20, 0, time.UTC), false},
+		{"20250305101520", time.Date(2025, 3, 5, 10, 15, 20, 0, time.UTC), false},
+		{"2025030510152", time.Time{}, true},
+		{"", time.Time{}, true},
+		{"broken", time.Time{}, true},
+		{"ABCDEFGHIJKLMNOPQRSTUVW", time.Time{}, true},
+		{"20251305101520123456000", time.Time{}, true},
+	} {
+		owner, err := ownerTime(tc.owner, time.UTC)
+		if tc.err {
+			assert.Error(t, err, tc.owner)
+		} else {
+			assert.NoError(t, err, tc.owner)
+			assert.Equal(t, tc.expected, owner, tc.owner)
+		}
+	}
+}
+
+func TestTopKeys(t *testing.T) {
+	counts := map[string]int{"VBAK": 5, "MARA": 2, "VBAP": 5, "KNA1": 1, "LIKP": 2}
+	for _, tc := range []struct {
+		n        int
+		expected []string
+	}{
+		// the ties are ordered by key
+		{0, []string{"VBAK", "VBAP", "LIKP", "MARA", "KNA1"}},
+		{-1, []string{"VBAK", "VBAP", "LIKP", "MARA", "KNA1"}},
+		{1, []string{"VBAK"}},
+		{3, []string{"VBAK", "VBAP", "LIKP"}},
+		{10, []string{"VBAK", "VBAP", "LIKP", "MARA", "KNA1"}},
+	} {
+		assert.Equal(t, tc.expected, topKeys(counts, tc.n), "n=%d", tc.n)
+	}
+	assert.Empty(t, topKeys(map[string]int{}, 3))
+}
@@ -0,0 +1,171 @@
+package ha
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+type haCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+}
+
+func NewCollector(webService sapcontrol.WebService) (*haCollector, error) {
+
+	c := &haCollector{
+		collector.NewDefaultCollector("ha"),
+		webService,
+		config.NewLogger("ha"),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.SetDescriptor("check", "State of a single HA configuration check",
+		[]string{"check", "category", "description", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("checks_failed", "Number of HA configuration checks in state ERROR",
+		[]string{"check", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("checks_warning", "Number of HA configuration checks in state WARNING",
+		[]string{"check", "instance_name", "instance_number", "SID", "instance_hostname"})
+
+	c.SetDescriptor("failover_config_info", "HA failover configuration of the instance, the value is always 1",
+		[]string{"ha_active", "ha_product_version", "ha_sap_interface_version", "ha_active_node", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("failover_node", "HA cluster node configured for the instance failover, 1 if the instance is active on the node, 0 otherwise",
+		[]string{"node", "instance_name", "instance_number", "SID", "instance_hostname"})
+
+	return c, nil
+}
+
+func (c *haCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting HA metrics")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := collector.RecordConcurrently(ctx, []func(ctx context.Context, ch chan<- prometheus.Metric) error{
+		c.recordHAChecks,
+		c.recordHAFailoverConfig,
+	}, ch)
+
+	for _, err := range errs {
+		log.Errorf("HA Collector: %s", err)
+	}
+}
+
+func (c *haCollector) recordHAChecks(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordHAChecks collecting")
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordHAChecks")
+	}
+	log.Debugf("recordHAChecks: Instances in the list: %d", len(instanceInfo))
+
+	for _, instance := range instanceInfo {
+
+		url := instance.Endpoint
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		// instances without an HA connector answer with a fault, that is expected, so it is only logged in debug
+		configChecks, err := c.webService.HACheckConfig(ctx, url)
+		if err != nil {
+			log.Debugf("recordHAChecks: %v", err)
+		} else {
+			c.sendChecks(ch, "config", configChecks.Checks, commonLabels)
+		}
+
+		failoverChecks, err := c.webService.HACheckFailoverConfig(ctx, url)
+		if err != nil {
+			log.Debugf("recordHAChecks: %v", err)
+		} else {
+			c.sendChecks(ch, "failover_config", failoverChecks.Checks, commonLabels)
+		}
+	}
+	return nil
+}
+
+func (c *haCollector) sendChecks(ch chan<- prometheus.Metric, check string, checks []*sapcontrol.HACheck, commonLabels []string) {
+	log := c.logger
+
+	failed := 0
+	warning := 0
+	for _, haCheck := range checks {
+		switch haCheck.State {
+		case sapcontrol.HA_VERIFICATION_STATE_ERROR:
+			failed++
+		case sapcontrol.HA_VERIFICATION_STATE_WARNING:
+			warning++
+		}
+		state, err := sapcontrol.HAStateToFloat(haCheck.State)
+		if err != nil {
+			log.Warnf("HA check %q state %q: %s", haCheck.Description, haCheck.State, err)
+			continue
+		}
+		labels := append([]string{check, haCheck.Category, haCheck.Description}, commonLabels...)
+		ch <- c.MakeGaugeMetric("check", state, labels...)
+	}
+
+	labels := append([]string{check}, commonLabels...)
+	ch <- c.MakeGaugeMetric("checks_failed", float64(failed), labels...)
+	ch <- c.MakeGaugeMetric("checks_warning", float64(warning), labels...)
+}
+
+func (c *haCollector) recordHAFailoverConfig(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordHAFailoverConfig collecting")
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordHAFailoverConfig")
+	}
+
+	for _, instance := range instanceInfo {
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		failoverConfig, err := c.webService.HAGetFailoverConfig(ctx, instance.Endpoint)
+		if err != nil {
+			log.Debugf("recordHAFailoverConfig: %v", err)
+			continue
+		}
+
+		labels := append([]string{
+			strconv.FormatBool(failoverConfig.HAActive),
+			failoverConfig.HAProductVersion,
+			failoverConfig.HASAPInterfaceVersion,
+			failoverConfig.HAActiveNode,
+		}, commonLabels...)
+		ch <- c.MakeGaugeMetric("failover_config_info", 1, labels...)
+
+		for _, node := range failoverConfig.HANodes {
+			active := 0.0
+			if node == failoverConfig.HAActiveNode {
+				active = 1
+			}
+			labels := append([]string{node}, commonLabels...)
+			ch <- c.MakeGaugeMetric("failover_node", active, labels...)
+		}
+	}
+	return nil
+}
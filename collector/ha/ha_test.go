@@ -0,0 +1,114 @@
+package ha
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+// one ASCS instance managed by the cluster and one dialog instance without HA connector
+func expectHAInstances(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0}, Name: "ASCS00", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil).AnyTimes()
+}
+
+func TestHAChecksMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectHAInstances(mockWebService)
+	mockWebService.EXPECT().HACheckConfig(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.HACheckConfigResponse{
+		Checks: []*sapcontrol.HACheck{
+			{State: sapcontrol.HA_VERIFICATION_STATE_SUCCESS, Category: "SAP-Configuration", Description: "Redundancy of message server"},
+			{State: sapcontrol.HA_VERIFICATION_STATE_ERROR, Category: "SAP-Configuration", Description: "Redundancy of enqueue server"},
+		},
+	}, nil)
+	mockWebService.EXPECT().HACheckFailoverConfig(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.HACheckFailoverConfigResponse{
+		Checks: []*sapcontrol.HACheck{
+			{State: sapcontrol.HA_VERIFICATION_STATE_WARNING, Category: "HA-Configuration", Description: "HA failover configuration"},
+		},
+	}, nil)
+	// the dialog instance has no HA connector configured
+	mockWebService.EXPECT().HACheckConfig(gomock.Any(), "http://sapha1pas:50113").Return(nil, errors.New("HA connector not configured"))
+	mockWebService.EXPECT().HACheckFailoverConfig(gomock.Any(), "http://sapha1pas:50113").Return(nil, errors.New("HA connector not configured"))
+	mockWebService.EXPECT().HAGetFailoverConfig(gomock.Any(), gomock.Any()).Return(nil, errors.New("HA connector not configured")).AnyTimes()
+
+	expectedMetrics := `
+	# HELP sap_ha_check State of a single HA configuration check
+	# TYPE sap_ha_check gauge
+	sap_ha_check{SID="HA1",category="HA-Configuration",check="failover_config",description="HA failover configuration",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 3
+	sap_ha_check{SID="HA1",category="SAP-Configuration",check="config",description="Redundancy of enqueue server",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 4
+	sap_ha_check{SID="HA1",category="SAP-Configuration",check="config",description="Redundancy of message server",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 2
+	# HELP sap_ha_checks_failed Number of HA configuration checks in state ERROR
+	# TYPE sap_ha_checks_failed gauge
+	sap_ha_checks_failed{SID="HA1",check="config",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 1
+	sap_ha_checks_failed{SID="HA1",check="failover_config",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 0
+	# HELP sap_ha_checks_warning Number of HA configuration checks in state WARNING
+	# TYPE sap_ha_checks_warning gauge
+	sap_ha_checks_warning{SID="HA1",check="config",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 0
+	sap_ha_checks_warning{SID="HA1",check="failover_config",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 1
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_ha_check", "sap_ha_checks_failed", "sap_ha_checks_warning")
+	assert.NoError(t, err)
+}
+
+func TestHAFailoverConfigMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectHAInstances(mockWebService)
+	mockWebService.EXPECT().HACheckConfig(gomock.Any(), gomock.Any()).Return(nil, errors.New("HA connector not configured")).AnyTimes()
+	mockWebService.EXPECT().HACheckFailoverConfig(gomock.Any(), gomock.Any()).Return(nil, errors.New("HA connector not configured")).AnyTimes()
+	mockWebService.EXPECT().HAGetFailoverConfig(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.HAGetFailoverConfigResponse{
+		HAActive:              true,
+		HAProductVersion:      "Pacemaker",
+		HASAPInterfaceVersion: "sap_cluster_connector",
+		HAActiveNode:          "node1",
+		HANodes:               []string{"node1", "node2"},
+	}, nil)
+	mockWebService.EXPECT().HAGetFailoverConfig(gomock.Any(), "http://sapha1pas:50113").Return(nil, errors.New("HA connector not configured"))
+
+	expectedMetrics := `
+	# HELP sap_ha_failover_config_info HA failover configuration of the instance, the value is always 1
+	# TYPE sap_ha_failover_config_info gauge
+	sap_ha_failover_config_info{SID="HA1",ha_active="true",ha_active_node="node1",ha_product_version="Pacemaker",ha_sap_interface_version="sap_cluster_connector",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0"} 1
+	# HELP sap_ha_failover_node HA cluster node configured for the instance failover, 1 if the instance is active on the node, 0 otherwise
+	# TYPE sap_ha_failover_node gauge
+	sap_ha_failover_node{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0",node="node1"} 1
+	sap_ha_failover_node{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0",node="node2"} 0
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_ha_failover_config_info", "sap_ha_failover_node")
+	assert.NoError(t, err)
+}
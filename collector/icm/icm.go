@@ -0,0 +1,296 @@
+package icm
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// upper bounds (seconds) of the connection timeout histograms
+var timeoutBuckets = []float64{5, 10, 30, 60, 120, 300, 600, 1800, 3600}
+
+type icmCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+}
+
+func NewCollector(webService sapcontrol.WebService) (*icmCollector, error) {
+
+	c := &icmCollector{
+		collector.NewDefaultCollector("icm"),
+		webService,
+		config.NewLogger("icm"),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.SetDescriptor("threads", "ICM worker thread counts by status",
+		[]string{"status", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("thread_requests", "Requests processed by ICM worker thread",
+		[]string{"thread_id", "thread_name", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("threads_utilization", "Ratio of busy ICM worker threads to all ICM worker threads",
+		[]string{"instance_name", "instance_number", "SID", "instance_hostname"})
+
+	c.SetDescriptor("connections", "ICM connection counts by protocol, role and request type",
+		[]string{"protocol", "role", "request_type", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("connections_keepalive_timeout_seconds", "Distribution of the keep-alive timeout of ICM connections",
+		[]string{"instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("connections_processing_timeout_seconds", "Distribution of the processing timeout of ICM connections",
+		[]string{"instance_name", "instance_number", "SID", "instance_hostname"})
+
+	c.SetDescriptor("cache_entries", "ICM server cache entry counts by validity",
+		[]string{"cache", "valid", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("cache_size_bytes", "Total size of the ICM server cache entries",
+		[]string{"cache", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("cache_oldest_entry_age_seconds", "Age of the oldest ICM server cache entry, since its creation",
+		[]string{"cache", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("cache_oldest_access_age_seconds", "Time since the least recently accessed ICM server cache entry was accessed",
+		[]string{"cache", "instance_name", "instance_number", "SID", "instance_hostname"})
+
+	return c, nil
+}
+
+func (c *icmCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting ICM metrics")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := collector.RecordConcurrently(ctx, []func(ctx context.Context, ch chan<- prometheus.Metric) error{
+		c.recordICMThreads,
+		c.recordICMConnections,
+		c.recordICMCache,
+	}, ch)
+
+	for _, err := range errs {
+		log.Errorf("ICM Collector: %s", err)
+	}
+}
+
+// returns the instances that run an ICM process
+func (c *icmCollector) icmInstances(ctx context.Context) ([]sapcontrol.InstanceInfo, error) {
+	log := c.logger
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("icmInstances: Instances in the list: %d", len(instanceInfo))
+
+	instances := make([]sapcontrol.InstanceInfo, 0, len(instanceInfo))
+	for _, instance := range instanceInfo {
+		processInfo, err := c.webService.GetCachedProcessList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("icmInstances: %v", err)
+			continue
+		}
+		for _, process := range processInfo {
+			if strings.Contains(process.Name, "icman") {
+				instances = append(instances, instance)
+				break
+			}
+		}
+	}
+	return instances, nil
+}
+
+func (c *icmCollector) recordICMThreads(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordICMThreads collecting")
+
+	instanceInfo, err := c.icmInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordICMThreads")
+	}
+
+	for _, instance := range instanceInfo {
+
+		threadList, err := c.webService.ICMGetThreadList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordICMThreads: %v", err)
+			continue
+		}
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		threadCounts := make(map[string]int)
+		busy := 0
+		for _, thread := range threadList.Threads {
+			threadCounts[thread.Status]++
+			if isThreadBusy(thread.Status) {
+				busy++
+			}
+			labels := append([]string{thread.Id, thread.Name}, commonLabels...)
+			ch <- c.MakeCounterMetric("thread_requests", float64(thread.Requests), labels...)
+		}
+
+		for status, count := range threadCounts {
+			labels := append([]string{status}, commonLabels...)
+			ch <- c.MakeGaugeMetric("threads", float64(count), labels...)
+		}
+
+		if total := len(threadList.Threads); total > 0 {
+			ch <- c.MakeGaugeMetric("threads_utilization", float64(busy)/float64(total), commonLabels...)
+		}
+	}
+	return nil
+}
+
+func (c *icmCollector) recordICMConnections(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordICMConnections collecting")
+
+	instanceInfo, err := c.icmInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordICMConnections")
+	}
+
+	type connectionKey struct {
+		protocol    string
+		role        string
+		requestType string
+	}
+
+	for _, instance := range instanceInfo {
+
+		connectionList, err := c.webService.ICMGetConnectionList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordICMConnections: %v", err)
+			continue
+		}
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		// peer addresses and connection ids are deliberately not exported, they would explode the series cardinality
+		connectionCounts := make(map[connectionKey]int)
+		keepAlive := make([]float64, 0, len(connectionList.Connections))
+		procTimeout := make([]float64, 0, len(connectionList.Connections))
+		for _, connection := range connectionList.Connections {
+			connectionCounts[connectionKey{connection.Protocol, connection.Role, connection.Requesttype}]++
+			keepAlive = append(keepAlive, float64(connection.Keepalivetimeout))
+			procTimeout = append(procTimeout, float64(connection.Proctimeout))
+		}
+
+		for key, count := range connectionCounts {
+			labels := append([]string{key.protocol, key.role, key.requestType}, commonLabels...)
+			ch <- c.MakeGaugeMetric("connections", float64(count), labels...)
+		}
+
+		count, sum, buckets := collector.HistogramBuckets(keepAlive, timeoutBuckets)
+		ch <- c.MakeHistogramMetric("connections_keepalive_timeout_seconds", count, sum, buckets, commonLabels...)
+		count, sum, buckets = collector.HistogramBuckets(procTimeout, timeoutBuckets)
+		ch <- c.MakeHistogramMetric("connections_processing_timeout_seconds", count, sum, buckets, commonLabels...)
+	}
+	return nil
+}
+
+func (c *icmCollector) recordICMCache(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordICMCache collecting")
+
+	instanceInfo, err := c.icmInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordICMCache")
+	}
+	loc := c.webService.GetMyClient().GetTimeLocation()
+
+	type cacheStats struct {
+		valid        int
+		invalid      int
+		size         int64
+		oldestCreate time.Time
+		oldestAccess time.Time
+	}
+
+	for _, instance := range instanceInfo {
+
+		cacheEntries, err := c.webService.ICMGetCacheEntries(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordICMCache: %v", err)
+			continue
+		}
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		caches := make(map[string]*cacheStats)
+		for _, entry := range cacheEntries.Entries {
+			stats, ok := caches[entry.Cache]
+			if !ok {
+				stats = &cacheStats{}
+				caches[entry.Cache] = stats
+			}
+			if entry.Valid {
+				stats.valid++
+			} else {
+				stats.invalid++
+			}
+			stats.size += entry.Size
+
+			if t, err := sapcontrol.ParseSAPTime(entry.Creationtime, loc); err == nil {
+				if stats.oldestCreate.IsZero() || t.Before(stats.oldestCreate) {
+					stats.oldestCreate = t
+				}
+			} else {
+				log.Debugf("ICM cache entry creation time: %s", err)
+			}
+			if t, err := sapcontrol.ParseSAPTime(entry.Lastaccesstime, loc); err == nil {
+				if stats.oldestAccess.IsZero() || t.Before(stats.oldestAccess) {
+					stats.oldestAccess = t
+				}
+			} else {
+				log.Debugf("ICM cache entry last access time: %s", err)
+			}
+		}
+
+		for cache, stats := range caches {
+			labels := append([]string{cache}, commonLabels...)
+			ch <- c.MakeGaugeMetric("cache_entries", float64(stats.valid), append([]string{cache, "true"}, commonLabels...)...)
+			ch <- c.MakeGaugeMetric("cache_entries", float64(stats.invalid), append([]string{cache, "false"}, commonLabels...)...)
+			ch <- c.MakeGaugeMetric("cache_size_bytes", float64(stats.size), labels...)
+			if !stats.oldestCreate.IsZero() {
+				ch <- c.MakeGaugeMetric("cache_oldest_entry_age_seconds", time.Since(stats.oldestCreate).Seconds(), labels...)
+			}
+			if !stats.oldestAccess.IsZero() {
+				ch <- c.MakeGaugeMetric("cache_oldest_access_age_seconds", time.Since(stats.oldestAccess).Seconds(), labels...)
+			}
+		}
+	}
+	return nil
+}
+
+// an ICM thread is idle while it waits for work in status "Available", any other status means it is serving a request
+func isThreadBusy(status string) bool {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "AVAILABLE", "":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,159 @@
+package icm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+// one ABAP instance running the ICM and one ASCS instance without it
+func expectICMInstances(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0}, Name: "ASCS00", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), "http://sapha1pas:50113").Return([]sapcontrol.ProcessInfo{
+		{OSProcess: sapcontrol.OSProcess{Name: "disp+work"}},
+		{OSProcess: sapcontrol.OSProcess{Name: "icman"}},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), "http://sapha1as:50013").Return([]sapcontrol.ProcessInfo{
+		{OSProcess: sapcontrol.OSProcess{Name: "msg_server"}},
+	}, nil).AnyTimes()
+}
+
+func TestICMThreadsMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectICMInstances(mockWebService)
+	mockWebService.EXPECT().ICMGetThreadList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetThreadListResponse{
+		Threads: []*sapcontrol.ICMThread{
+			{Name: "Thr 1", Id: "1", Requests: 120, Status: "Running"},
+			{Name: "Thr 2", Id: "2", Requests: 80, Status: "Available"},
+			{Name: "Thr 3", Id: "3", Requests: 7, Status: "Available"},
+			{Name: "Thr 4", Id: "4", Requests: 0, Status: "Available"},
+		},
+	}, nil)
+	mockWebService.EXPECT().ICMGetConnectionList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetConnectionListResponse{}, nil)
+	mockWebService.EXPECT().ICMGetCacheEntries(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetCacheEntriesResponse{}, nil)
+
+	expectedMetrics := `
+	# HELP sap_icm_thread_requests Requests processed by ICM worker thread
+	# TYPE sap_icm_thread_requests counter
+	sap_icm_thread_requests{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",thread_id="1",thread_name="Thr 1"} 120
+	sap_icm_thread_requests{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",thread_id="2",thread_name="Thr 2"} 80
+	sap_icm_thread_requests{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",thread_id="3",thread_name="Thr 3"} 7
+	sap_icm_thread_requests{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",thread_id="4",thread_name="Thr 4"} 0
+	# HELP sap_icm_threads ICM worker thread counts by status
+	# TYPE sap_icm_threads gauge
+	sap_icm_threads{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Available"} 3
+	sap_icm_threads{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Running"} 1
+	# HELP sap_icm_threads_utilization Ratio of busy ICM worker threads to all ICM worker threads
+	# TYPE sap_icm_threads_utilization gauge
+	sap_icm_threads_utilization{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1"} 0.25
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_icm_threads", "sap_icm_thread_requests", "sap_icm_threads_utilization")
+	assert.NoError(t, err)
+}
+
+func TestICMConnectionsMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectICMInstances(mockWebService)
+	mockWebService.EXPECT().ICMGetThreadList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetThreadListResponse{}, nil)
+	mockWebService.EXPECT().ICMGetCacheEntries(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetCacheEntriesResponse{}, nil)
+	mockWebService.EXPECT().ICMGetConnectionList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetConnectionListResponse{
+		Connections: []*sapcontrol.ICMConnection{
+			{Conid: "1", Protocol: "HTTP", Role: "Server", Requesttype: "Normal", Peeraddress: "10.0.0.1", Keepalivetimeout: 30, Proctimeout: 60},
+			{Conid: "2", Protocol: "HTTP", Role: "Server", Requesttype: "Normal", Peeraddress: "10.0.0.2", Keepalivetimeout: 30, Proctimeout: 60},
+			{Conid: "3", Protocol: "HTTPS", Role: "Client", Requesttype: "Normal", Peeraddress: "10.0.0.3", Keepalivetimeout: 300, Proctimeout: 600},
+		},
+	}, nil)
+
+	expectedMetrics := `
+	# HELP sap_icm_connections ICM connection counts by protocol, role and request type
+	# TYPE sap_icm_connections gauge
+	sap_icm_connections{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",protocol="HTTP",request_type="Normal",role="Server"} 2
+	sap_icm_connections{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",protocol="HTTPS",request_type="Normal",role="Client"} 1
+	# HELP sap_icm_connections_keepalive_timeout_seconds Distribution of the keep-alive timeout of ICM connections
+	# TYPE sap_icm_connections_keepalive_timeout_seconds histogram
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="5"} 0
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="10"} 0
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="30"} 2
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="60"} 2
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="120"} 2
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="300"} 3
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="600"} 3
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="1800"} 3
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="3600"} 3
+	sap_icm_connections_keepalive_timeout_seconds_bucket{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",le="+Inf"} 3
+	sap_icm_connections_keepalive_timeout_seconds_sum{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1"} 360
+	sap_icm_connections_keepalive_timeout_seconds_count{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1"} 3
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_icm_connections", "sap_icm_connections_keepalive_timeout_seconds")
+	assert.NoError(t, err)
+}
+
+func TestICMCacheMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	expectICMInstances(mockWebService)
+	mockWebService.EXPECT().ICMGetThreadList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetThreadListResponse{}, nil)
+	mockWebService.EXPECT().ICMGetConnectionList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetConnectionListResponse{}, nil)
+	mockWebService.EXPECT().ICMGetCacheEntries(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ICMGetCacheEntriesResponse{
+		Entries: []*sapcontrol.ICMCacheEntry{
+			{Name: "/sap/public/bc/ur/a.js", Size: 1024, Valid: true, Cache: "ICM"},
+			{Name: "/sap/public/bc/ur/b.css", Size: 2048, Valid: true, Cache: "ICM"},
+			{Name: "/sap/public/bc/ur/c.gif", Size: 512, Valid: false, Cache: "ICM"},
+		},
+	}, nil)
+
+	expectedMetrics := `
+	# HELP sap_icm_cache_entries ICM server cache entry counts by validity
+	# TYPE sap_icm_cache_entries gauge
+	sap_icm_cache_entries{SID="HA1",cache="ICM",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",valid="false"} 1
+	sap_icm_cache_entries{SID="HA1",cache="ICM",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",valid="true"} 2
+	# HELP sap_icm_cache_size_bytes Total size of the ICM server cache entries
+	# TYPE sap_icm_cache_size_bytes gauge
+	sap_icm_cache_size_bytes{SID="HA1",cache="ICM",instance_hostname="sapha1pas",instance_name="D01",instance_number="1"} 3584
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_icm_cache_entries", "sap_icm_cache_size_bytes", "sap_icm_cache_oldest_entry_age_seconds")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,124 @@
+package inventory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// the software components are installed in the database, any ABAP instance of the system returns the same list
+type componentList struct {
+	sid        string
+	components []*sapcontrol.ABAPComponent
+}
+
+type inventoryCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+}
+
+func NewCollector(webService sapcontrol.WebService) (*inventoryCollector, error) {
+
+	c := &inventoryCollector{
+		collector.NewDefaultCollector("abap"),
+		webService,
+		config.NewLogger("inventory"),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.SetDescriptor("component_info", "ABAP software component installed in the system, the value is always 1",
+		[]string{"component", "release", "patch_level", "component_type", "description", "SID"})
+
+	return c, nil
+}
+
+func (c *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting Inventory metrics")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := c.recordComponents(ctx, ch)
+	if err != nil {
+		log.Errorf("Inventory Collector: %s", err)
+	}
+}
+
+func (c *inventoryCollector) recordComponents(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordComponents collecting")
+
+	list, err := c.getCachedComponentList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordComponents")
+	}
+
+	for _, component := range list.components {
+		ch <- c.MakeGaugeMetric("component_info", 1,
+			component.Component, component.Release, component.Patchlevel, component.Componenttype, component.Description, list.sid)
+	}
+	return nil
+}
+
+// Returns the software component list, uses memory cache with inventory_cache_ttl, the list only changes with a system update.
+func (c *inventoryCollector) getCachedComponentList(ctx context.Context) (*componentList, error) {
+	myClient := c.webService.GetMyClient()
+	v := myClient.GetMyConfig().Viper
+
+	// read before GetOrSet, the cache manager is locked while the set function runs
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getCachedComponentList")
+	}
+
+	value := myClient.GetCacheManager().GetOrSet("ABAPComponentList",
+		func() (interface{}, time.Duration) {
+			list, err := c.getComponentList(ctx, instanceInfo)
+			if err != nil {
+				c.logger.Errorf("getCachedComponentList: %v", err)
+				// retry with the next instance list refresh rather than after the long TTL
+				ttl := v.GetDuration("cache_ttl")
+				if ttl == 0 {
+					ttl = 30 * time.Second
+				}
+				return nil, ttl
+			}
+			return list, v.GetDuration("inventory_cache_ttl")
+		})
+	if list, ok := value.(*componentList); ok && list != nil {
+		return list, nil
+	}
+	return nil, errors.New("ABAPGetComponentList error")
+}
+
+// reads the software component list from the first ABAP instance that answers
+func (c *inventoryCollector) getComponentList(ctx context.Context, instanceInfo []sapcontrol.InstanceInfo) (*componentList, error) {
+	log := c.logger
+
+	for _, instance := range instanceInfo {
+
+		if !strings.Contains(strings.ToUpper(instance.Features), "ABAP") {
+			continue
+		}
+
+		response, err := c.webService.ABAPGetComponentList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Warnf("getComponentList: %v", err)
+			continue
+		}
+		return &componentList{instance.SID, response.Components}, nil
+	}
+	return nil, errors.New("getComponentList: no ABAP instance returned the component list")
+}
@@ -0,0 +1,100 @@
+package inventory
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+func newMockWebService(ctrl *gomock.Controller) *mock_sapcontrol.MockWebService {
+	return fixtures.NewMockWebService(ctrl, map[string]interface{}{"inventory_cache_ttl": "6h"})
+}
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestComponentInfoMetricCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0, Features: "MESSAGESERVER|ENQUE"}, Name: "ASCS00", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1aas", InstanceNr: 2, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D02", SID: "HA1", Endpoint: "http://sapha1aas:50213"},
+	}, nil).Times(2)
+	// the first ABAP instance does not answer, the list is read once from the second one
+	mockWebService.EXPECT().ABAPGetComponentList(gomock.Any(), "http://sapha1pas:50113").Return(nil, errors.New("connection refused")).Times(1)
+	mockWebService.EXPECT().ABAPGetComponentList(gomock.Any(), "http://sapha1aas:50213").Return(&sapcontrol.ABAPGetComponentListResponse{
+		Components: []*sapcontrol.ABAPComponent{
+			{Component: "SAP_BASIS", Release: "757", Patchlevel: "0002", Componenttype: "S", Description: "SAP Basis Component"},
+			{Component: "SAP_ABA", Release: "75H", Patchlevel: "0002", Componenttype: "S", Description: "Cross-Application Component"},
+		},
+	}, nil).Times(1)
+
+	expectedMetrics := `
+	# HELP sap_abap_component_info ABAP software component installed in the system, the value is always 1
+	# TYPE sap_abap_component_info gauge
+	sap_abap_component_info{SID="HA1",component="SAP_ABA",component_type="S",description="Cross-Application Component",patch_level="0002",release="75H"} 1
+	sap_abap_component_info{SID="HA1",component="SAP_BASIS",component_type="S",description="SAP Basis Component",patch_level="0002",release="757"} 1
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics))
+	assert.NoError(t, err)
+	// served from the cache
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics))
+	assert.NoError(t, err)
+}
+
+func TestComponentListWithInstanceListCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl)
+	// the instance list is read through the cache manager shared with the component list
+	webService := sapcontrol.NewWebService(mockWebService.GetMyClient())
+	webService.GetMyClient().GetCacheManager().GetOrSet("InstanceInfo", func() (interface{}, time.Duration) {
+		return []sapcontrol.InstanceInfo{
+			{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+		}, time.Minute
+	})
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).DoAndReturn(webService.GetCachedInstanceList)
+	mockWebService.EXPECT().ABAPGetComponentList(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPGetComponentListResponse{
+		Components: []*sapcontrol.ABAPComponent{
+			{Component: "SAP_BASIS", Release: "757", Patchlevel: "0002", Componenttype: "S", Description: "SAP Basis Component"},
+		},
+	}, nil)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	done := make(chan int)
+	go func() {
+		done <- testutil.CollectAndCount(collector)
+	}()
+	select {
+	case count := <-done:
+		assert.Equal(t, 1, count)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Collect did not return, the cache manager is locked")
+	}
+}
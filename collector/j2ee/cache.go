@@ -0,0 +1,107 @@
+package j2ee
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+func (c *j2eeCollector) setCacheDescriptors() {
+	cacheLabels := []string{"process", "cache", "cache_type", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("cache_size_bytes", "Size of the AS Java cache region", cacheLabels)
+	c.SetDescriptor("cache_objects", "Objects in the AS Java cache region, by usage",
+		append([]string{"usage"}, cacheLabels...))
+	c.SetDescriptor("cache_gets", "Get requests to the AS Java cache region", cacheLabels)
+	c.SetDescriptor("cache_hits", "Get requests served by the AS Java cache region", cacheLabels)
+	c.SetDescriptor("cache_misses", "Get requests not served by the AS Java cache region", cacheLabels)
+	c.SetDescriptor("cache_puts", "Put requests to the AS Java cache region", cacheLabels)
+	c.SetDescriptor("cache_evictions", "Objects evicted from the AS Java cache region", cacheLabels)
+	c.SetDescriptor("cache_state", "AS Java cache region state, following the SAP state colors", cacheLabels)
+
+	tableLabels := []string{"table", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("shared_table_used", "Used entries of the AS Java shared table", tableLabels)
+	c.SetDescriptor("shared_table_peak", "Peak used entries of the AS Java shared table", tableLabels)
+	c.SetDescriptor("shared_table_limit", "Entries limit of the AS Java shared table", tableLabels)
+	c.SetDescriptor("shared_table_state", "AS Java shared table state, following the SAP state colors", tableLabels)
+}
+
+func (c *j2eeCollector) recordCaches(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordCaches collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordCaches")
+	}
+
+	for _, instance := range instances {
+
+		cacheStatistic, err := c.webService.J2EEGetCacheStatistic(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordCaches: %v", err)
+			continue
+		}
+
+		for _, cache := range cacheStatistic.Caches {
+			labels := append([]string{cache.Processname, cache.Cachename, cache.Type}, commonLabels(instance)...)
+
+			ch <- c.MakeGaugeMetric("cache_size_bytes", float64(cache.Size), labels...)
+			ch <- c.MakeGaugeMetric("cache_objects", float64(cache.CachedObjects), append([]string{"cached"}, labels...)...)
+			ch <- c.MakeGaugeMetric("cache_objects", float64(cache.UsedObjects), append([]string{"used"}, labels...)...)
+			ch <- c.MakeCounterMetric("cache_gets", float64(cache.Gets), labels...)
+			ch <- c.MakeCounterMetric("cache_hits", float64(cache.Hits), labels...)
+			ch <- c.MakeCounterMetric("cache_misses", float64(cache.Gets-cache.Hits), labels...)
+			ch <- c.MakeCounterMetric("cache_puts", float64(cache.Puts), labels...)
+			ch <- c.MakeCounterMetric("cache_evictions", float64(cache.Evictions), labels...)
+
+			state, err := sapcontrol.StateColorToFloat(cache.Dispstatus)
+			if err != nil {
+				log.Warnf("recordCaches: cache %s of %s dispstatus %q: %s", cache.Cachename, cache.Processname, cache.Dispstatus, err)
+				continue
+			}
+			ch <- c.MakeGaugeMetric("cache_state", state, labels...)
+		}
+	}
+	return nil
+}
+
+func (c *j2eeCollector) recordSharedTables(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordSharedTables collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordSharedTables")
+	}
+
+	for _, instance := range instances {
+
+		sharedTableInfo, err := c.webService.J2EEGetSharedTableInfo(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordSharedTables: %v", err)
+			continue
+		}
+
+		for _, table := range sharedTableInfo.Tables {
+			labels := append([]string{table.Table}, commonLabels(instance)...)
+
+			ch <- c.MakeGaugeMetric("shared_table_used", float64(table.Used), labels...)
+			ch <- c.MakeGaugeMetric("shared_table_peak", float64(table.Peak), labels...)
+			ch <- c.MakeGaugeMetric("shared_table_limit", float64(table.Limit), labels...)
+
+			state, err := sapcontrol.StateColorToFloat(table.Dispstatus)
+			if err != nil {
+				log.Warnf("recordSharedTables: table %s dispstatus %q: %s", table.Table, table.Dispstatus, err)
+				continue
+			}
+			ch <- c.MakeGaugeMetric("shared_table_state", state, labels...)
+		}
+	}
+	return nil
+}
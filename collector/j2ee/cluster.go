@@ -0,0 +1,106 @@
+package j2ee
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func (c *j2eeCollector) setClusterDescriptors() {
+	msgLabels := []string{"service", "id", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("cluster_messages", "Messages exchanged by the AS Java cluster service", msgLabels)
+	c.SetDescriptor("cluster_message_bytes", "Bytes of the messages exchanged by the AS Java cluster service", msgLabels)
+	c.SetDescriptor("cluster_message_max_bytes", "Largest message exchanged by the AS Java cluster service", msgLabels)
+	c.SetDescriptor("cluster_p2p_messages", "Point-to-point messages exchanged by the AS Java cluster service, by message type",
+		append([]string{"message_type"}, msgLabels...))
+	c.SetDescriptor("cluster_broadcast_messages", "Broadcast messages exchanged by the AS Java cluster service, by message type",
+		append([]string{"message_type"}, msgLabels...))
+
+	c.SetDescriptor("component_status", "AS Java service or application status: 1 started, 0 stopped, -1 failed",
+		[]string{"component", "component_type", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("component_info", "AS Java service or application status as reported by the AS Java, the value is always 1",
+		[]string{"component", "component_type", "status", "expected_status", "startup_mode", "instance_name", "instance_number", "SID", "instance_hostname"})
+}
+
+// componentStatusToFloat maps the J2EEGetComponentList status text to the component_status value
+func componentStatusToFloat(status string) (float64, error) {
+	switch strings.ToLower(status) {
+	case "started":
+		return 1, nil
+	case "stopped":
+		return 0, nil
+	case "failed":
+		return -1, nil
+	default:
+		return 0, errors.New("unknown component status")
+	}
+}
+
+func (c *j2eeCollector) recordClusterMessages(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordClusterMessages collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordClusterMessages")
+	}
+
+	for _, instance := range instances {
+
+		clusterMsgList, err := c.webService.J2EEGetClusterMsgList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordClusterMessages: %v", err)
+			continue
+		}
+
+		for _, msg := range clusterMsgList.Messages {
+			labels := append([]string{msg.Service, msg.Id}, commonLabels(instance)...)
+
+			ch <- c.MakeCounterMetric("cluster_messages", float64(msg.Count), labels...)
+			ch <- c.MakeCounterMetric("cluster_message_bytes", float64(msg.Length), labels...)
+			ch <- c.MakeGaugeMetric("cluster_message_max_bytes", float64(msg.Maxlength), labels...)
+			ch <- c.MakeCounterMetric("cluster_p2p_messages", float64(msg.Countp2pmsg), append([]string{"message"}, labels...)...)
+			ch <- c.MakeCounterMetric("cluster_p2p_messages", float64(msg.Countp2prequest), append([]string{"request"}, labels...)...)
+			ch <- c.MakeCounterMetric("cluster_p2p_messages", float64(msg.Countp2preply), append([]string{"reply"}, labels...)...)
+			ch <- c.MakeCounterMetric("cluster_broadcast_messages", float64(msg.Countbroadcastmsg), append([]string{"message"}, labels...)...)
+			ch <- c.MakeCounterMetric("cluster_broadcast_messages", float64(msg.Countbroadcastrequest), append([]string{"request"}, labels...)...)
+		}
+	}
+	return nil
+}
+
+func (c *j2eeCollector) recordComponents(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordComponents collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordComponents")
+	}
+
+	for _, instance := range instances {
+
+		componentList, err := c.webService.J2EEGetComponentList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordComponents: %v", err)
+			continue
+		}
+
+		for _, component := range componentList.Components {
+			ch <- c.MakeGaugeMetric("component_info", 1,
+				append([]string{component.Name, component.Type, component.Status, component.Expectedstatus, component.Startupmode}, commonLabels(instance)...)...)
+
+			status, err := componentStatusToFloat(component.Status)
+			if err != nil {
+				log.Warnf("recordComponents: component %s status %q: %s", component.Name, component.Status, err)
+				continue
+			}
+			ch <- c.MakeGaugeMetric("component_status", status, append([]string{component.Name, component.Type}, commonLabels(instance)...)...)
+		}
+	}
+	return nil
+}
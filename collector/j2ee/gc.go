@@ -0,0 +1,135 @@
+package j2ee
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// GC events of a Java server process counted so far
+type gcHistory struct {
+	// start time of the newest event counted by the previous reads, and the counted events started at that time
+	mark   time.Time
+	events map[sapcontrol.GCInfo]bool
+	// the same for the events counted so far by the current read, moved to mark by advance
+	nextMark   time.Time
+	nextEvents map[sapcontrol.GCInfo]bool
+
+	counts   map[gcKind]float64
+	duration float64
+	cpuTime  float64
+	freed    float64
+}
+
+type gcKind struct {
+	gcType string
+	reason string
+}
+
+func newGCHistory() *gcHistory {
+	events := make(map[sapcontrol.GCInfo]bool)
+	return &gcHistory{
+		events:     events,
+		nextEvents: events,
+		counts:     make(map[gcKind]float64),
+	}
+}
+
+// counts the event unless a previous read already counted it.
+// The events are not ordered by time, so they are all compared with the mark of the previous reads.
+func (h *gcHistory) add(gc sapcontrol.GCInfo, start time.Time) {
+	if start.Before(h.mark) || (start.Equal(h.mark) && h.events[gc]) {
+		return
+	}
+	if start.After(h.nextMark) {
+		h.nextMark = start
+		h.nextEvents = make(map[sapcontrol.GCInfo]bool)
+	}
+	if start.Equal(h.nextMark) {
+		h.nextEvents[gc] = true
+	}
+
+	h.counts[gcKind{gc.Type, gc.Reason}]++
+	// duration and CPU time are reported in milliseconds
+	h.duration += float64(gc.Duration) / 1000
+	h.cpuTime += float64(gc.CpuTime) / 1000
+	h.freed += float64(gc.ObjBytesFreed + gc.ClsBytesFreed)
+}
+
+// moves the mark to the newest event of the current read, once all its events are added
+func (h *gcHistory) advance() {
+	h.mark, h.events = h.nextMark, h.nextEvents
+}
+
+func (c *j2eeCollector) setGCDescriptors() {
+	gcLabels := []string{"process", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("gc_count", "Java VM garbage collections by type and reason",
+		append([]string{"gc_type", "reason"}, gcLabels...))
+	c.SetDescriptor("gc_duration_seconds", "Total duration of the Java VM garbage collections", gcLabels)
+	c.SetDescriptor("gc_cpu_seconds", "Total CPU time of the Java VM garbage collections", gcLabels)
+	c.SetDescriptor("gc_freed_bytes", "Object and class bytes freed by the Java VM garbage collections", gcLabels)
+}
+
+func (c *j2eeCollector) recordGCHistory(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordGCHistory collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordGCHistory")
+	}
+
+	// scrapes may overlap, each GC event must be counted by only one of them
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, instance := range instances {
+
+		gcHistoryList, err := c.webService.J2EEGetVMGCHistory(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordGCHistory: %v", err)
+			continue
+		}
+
+		histories, ok := c.gcHistories[instance.Endpoint]
+		if !ok {
+			histories = make(map[string]*gcHistory)
+			c.gcHistories[instance.Endpoint] = histories
+		}
+
+		for _, gc := range gcHistoryList.GCs {
+			// only the order of the events matters, so the time zone is irrelevant
+			start, err := sapcontrol.ParseSAPTime(gc.StartTime, time.UTC)
+			if err != nil {
+				log.Warnf("recordGCHistory: GC of %s: %s", gc.Processname, err)
+				continue
+			}
+			history, ok := histories[gc.Processname]
+			if !ok {
+				history = newGCHistory()
+				histories[gc.Processname] = history
+			}
+			history.add(*gc, start)
+		}
+
+		for _, history := range histories {
+			history.advance()
+		}
+		for process, history := range histories {
+			labels := append([]string{process}, commonLabels(instance)...)
+			for kind, count := range history.counts {
+				ch <- c.MakeCounterMetric("gc_count", count, append([]string{kind.gcType, kind.reason}, labels...)...)
+			}
+			ch <- c.MakeCounterMetric("gc_duration_seconds", history.duration, labels...)
+			ch <- c.MakeCounterMetric("gc_cpu_seconds", history.cpuTime, labels...)
+			ch <- c.MakeCounterMetric("gc_freed_bytes", history.freed, labels...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package j2ee
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+func (c *j2eeCollector) setHeapDescriptors() {
+	heapLabels := []string{"process", "heap_type", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("heap_size_bytes", "Current size of the Java VM heap", heapLabels)
+	c.SetDescriptor("heap_committed_bytes", "Committed size of the Java VM heap", heapLabels)
+	c.SetDescriptor("heap_max_used_bytes", "Maximum used size of the Java VM heap", heapLabels)
+	c.SetDescriptor("heap_initial_bytes", "Initial size of the Java VM heap", heapLabels)
+	c.SetDescriptor("heap_max_bytes", "Maximum size of the Java VM heap", heapLabels)
+	c.SetDescriptor("heap_state", "Java VM heap state, following the SAP state colors", heapLabels)
+}
+
+func (c *j2eeCollector) recordHeap(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordHeap collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordHeap")
+	}
+
+	for _, instance := range instances {
+
+		heapInfo, err := c.webService.J2EEGetVMHeapInfo(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordHeap: %v", err)
+			continue
+		}
+
+		for _, heap := range heapInfo.Heaps {
+			labels := append([]string{heap.Processname, heap.Type}, commonLabels(instance)...)
+
+			// the heap sizes are reported in KB
+			ch <- c.MakeGaugeMetric("heap_size_bytes", float64(heap.Size)*1024, labels...)
+			ch <- c.MakeGaugeMetric("heap_committed_bytes", float64(heap.CommitSize)*1024, labels...)
+			ch <- c.MakeGaugeMetric("heap_max_used_bytes", float64(heap.MaxUsedSize)*1024, labels...)
+			ch <- c.MakeGaugeMetric("heap_initial_bytes", float64(heap.InitialSize)*1024, labels...)
+			ch <- c.MakeGaugeMetric("heap_max_bytes", float64(heap.MaxSize)*1024, labels...)
+
+			state, err := sapcontrol.StateColorToFloat(heap.Dispstatus)
+			if err != nil {
+				log.Warnf("recordHeap: heap %s of %s dispstatus %q: %s", heap.Type, heap.Processname, heap.Dispstatus, err)
+				continue
+			}
+			ch <- c.MakeGaugeMetric("heap_state", state, labels...)
+		}
+	}
+	return nil
+}
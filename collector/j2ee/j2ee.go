@@ -0,0 +1,105 @@
+package j2ee
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// instance feature of the AS Java, as reported by GetSystemInstanceList
+const j2eeFeature = "J2EE"
+
+type j2eeCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+	mu         sync.Mutex
+	// GC history already counted, by instance endpoint and process name
+	gcHistories map[string]map[string]*gcHistory
+	// tasks running longer than j2ee_thread_long_running_threshold at the last scrape
+	longRunningTasks map[threadTask]bool
+}
+
+func NewCollector(webService sapcontrol.WebService) (*j2eeCollector, error) {
+
+	c := &j2eeCollector{
+		collector.NewDefaultCollector("j2ee"),
+		webService,
+		config.NewLogger("j2ee"),
+		sync.Mutex{},
+		make(map[string]map[string]*gcHistory),
+		make(map[threadTask]bool),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.setHeapDescriptors()
+	c.setGCDescriptors()
+	c.setProcessDescriptors()
+	c.setThreadDescriptors()
+	c.setSessionDescriptors()
+	c.setCacheDescriptors()
+	c.setClusterDescriptors()
+
+	return c, nil
+}
+
+func (c *j2eeCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting J2EE metrics")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := collector.RecordConcurrently(ctx, []func(ctx context.Context, ch chan<- prometheus.Metric) error{
+		c.recordHeap,
+		c.recordGCHistory,
+		c.recordProcesses,
+		c.recordThreads,
+		c.recordWebSessions,
+		c.recordEJBSessions,
+		c.recordSessions,
+		c.recordCaches,
+		c.recordSharedTables,
+		c.recordClusterMessages,
+		c.recordComponents,
+	}, ch)
+
+	for _, err := range errs {
+		log.Errorf("J2EE Collector: %s", err)
+	}
+}
+
+// the instances running the AS Java
+func (c *j2eeCollector) j2eeInstances(ctx context.Context) ([]sapcontrol.InstanceInfo, error) {
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "j2eeInstances")
+	}
+	instances := []sapcontrol.InstanceInfo{}
+	for _, instance := range instanceInfo {
+		if strings.Contains(strings.ToUpper(instance.Features), j2eeFeature) {
+			instances = append(instances, instance)
+		}
+	}
+	return instances, nil
+}
+
+func commonLabels(instance sapcontrol.InstanceInfo) []string {
+	return []string{
+		instance.Name,
+		strconv.Itoa(int(instance.InstanceNr)),
+		instance.SID,
+		instance.Hostname,
+	}
+}
@@ -0,0 +1,367 @@
+package j2ee
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+// one AS Java instance and one ABAP instance, the recorders not under test get no data
+func expectJ2EEInstances(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapjp1ci", InstanceNr: 2, Features: "J2EE|IGS"}, Name: "J02", SID: "JP1", Endpoint: "http://sapjp1ci:50213"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetVMHeapInfo(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetVMHeapInfoResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetVMGCHistory(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetVMGCHistoryResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetProcessList(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetProcessListResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetThreadList(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetThreadListResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetWebSessionList2(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetWebSessionList2Response{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetEJBSessionList(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetEJBSessionListResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetSessionList(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetSessionListResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetCacheStatistic(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetCacheStatisticResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetSharedTableInfo(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetSharedTableInfoResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetClusterMsgList(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetClusterMsgListResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().J2EEGetComponentList(gomock.Any(), gomock.Any()).Return(&sapcontrol.J2EEGetComponentListResponse{}, nil).AnyTimes()
+	mockWebService.EXPECT().GetLokiClient().Return(nil).AnyTimes()
+}
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestHeapMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().J2EEGetVMHeapInfo(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetVMHeapInfoResponse{
+		Heaps: []*sapcontrol.HeapInfo{
+			{Processname: "server0", Type: "Old Generation", Size: 1024, CommitSize: 2048, MaxUsedSize: 1536, InitialSize: 512, MaxSize: 4096, Dispstatus: sapcontrol.STATECOLOR_GREEN},
+		},
+	}, nil)
+	expectJ2EEInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_heap_max_bytes Maximum size of the Java VM heap
+	# TYPE sap_j2ee_heap_max_bytes gauge
+	sap_j2ee_heap_max_bytes{SID="JP1",heap_type="Old Generation",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 4.194304e+06
+	# HELP sap_j2ee_heap_size_bytes Current size of the Java VM heap
+	# TYPE sap_j2ee_heap_size_bytes gauge
+	sap_j2ee_heap_size_bytes{SID="JP1",heap_type="Old Generation",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 1.048576e+06
+	# HELP sap_j2ee_heap_state Java VM heap state, following the SAP state colors
+	# TYPE sap_j2ee_heap_state gauge
+	sap_j2ee_heap_state{SID="JP1",heap_type="Old Generation",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 2
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_heap_max_bytes", "sap_j2ee_heap_size_bytes", "sap_j2ee_heap_state")
+	assert.NoError(t, err)
+}
+
+func TestGCHistoryCountedOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := &sapcontrol.GCInfo{Processname: "server0", Type: "minor", Reason: "allocation failure", StartTime: "2025 03 01 10:00:00", Duration: 200, CpuTime: 150, ObjBytesFreed: 1000, ClsBytesFreed: 24}
+	second := &sapcontrol.GCInfo{Processname: "server0", Type: "full", Reason: "system.gc", StartTime: "2025 03 01 10:00:30", Duration: 1500, CpuTime: 1400, ObjBytesFreed: 5000}
+	third := &sapcontrol.GCInfo{Processname: "server0", Type: "minor", Reason: "allocation failure", StartTime: "2025 03 01 10:01:00", Duration: 300, CpuTime: 250, ObjBytesFreed: 2000}
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	gomock.InOrder(
+		mockWebService.EXPECT().J2EEGetVMGCHistory(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetVMGCHistoryResponse{
+			GCs: []*sapcontrol.GCInfo{first, second},
+		}, nil),
+		mockWebService.EXPECT().J2EEGetVMGCHistory(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetVMGCHistoryResponse{
+			GCs: []*sapcontrol.GCInfo{second, third},
+		}, nil),
+	)
+	expectJ2EEInstances(mockWebService)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	testutil.CollectAndCount(collector)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_gc_count Java VM garbage collections by type and reason
+	# TYPE sap_j2ee_gc_count counter
+	sap_j2ee_gc_count{SID="JP1",gc_type="full",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",reason="system.gc"} 1
+	sap_j2ee_gc_count{SID="JP1",gc_type="minor",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",reason="allocation failure"} 2
+	# HELP sap_j2ee_gc_duration_seconds Total duration of the Java VM garbage collections
+	# TYPE sap_j2ee_gc_duration_seconds counter
+	sap_j2ee_gc_duration_seconds{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 2
+	# HELP sap_j2ee_gc_freed_bytes Object and class bytes freed by the Java VM garbage collections
+	# TYPE sap_j2ee_gc_freed_bytes counter
+	sap_j2ee_gc_freed_bytes{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 8024
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_gc_count", "sap_j2ee_gc_duration_seconds", "sap_j2ee_gc_freed_bytes")
+	assert.NoError(t, err)
+}
+
+func TestGCHistoryReverseOrder(t *testing.T) {
+	first := sapcontrol.GCInfo{Processname: "server0", Type: "minor", StartTime: "2025 03 01 10:00:00", Duration: 200}
+	second := sapcontrol.GCInfo{Processname: "server0", Type: "full", StartTime: "2025 03 01 10:00:30", Duration: 1500}
+	third := sapcontrol.GCInfo{Processname: "server0", Type: "minor", StartTime: "2025 03 01 10:01:00", Duration: 300}
+	start := func(gc sapcontrol.GCInfo) time.Time {
+		t, _ := sapcontrol.ParseSAPTime(gc.StartTime, time.UTC)
+		return t
+	}
+
+	history := newGCHistory()
+	for _, reads := range [][]sapcontrol.GCInfo{
+		{second, first},
+		{third, second, first},
+		{third, second},
+	} {
+		for _, gc := range reads {
+			history.add(gc, start(gc))
+		}
+		history.advance()
+	}
+
+	assert.Equal(t, map[gcKind]float64{{"minor", ""}: 2, {"full", ""}: 1}, history.counts)
+	assert.Equal(t, 2.0, history.duration)
+}
+
+func TestProcessMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().J2EEGetProcessList(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetProcessListResponse{
+		Processes: []*sapcontrol.J2EEProcess{
+			{Name: "server0", Type: "J2EE Server", State: sapcontrol.J2EE_PSTATE_RUNNING, Statetext: "Running", RestartCount: 0},
+			{Name: "server1", Type: "J2EE Server", State: sapcontrol.J2EE_PSTATE_STOPPED, Statetext: "Stopped", RestartCount: 3, ErrorCount: 3},
+		},
+	}, nil)
+	expectJ2EEInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_process_restarts AS Java node restarts by jcontrol
+	# TYPE sap_j2ee_process_restarts counter
+	sap_j2ee_process_restarts{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",process_type="J2EE Server"} 0
+	sap_j2ee_process_restarts{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server1",process_type="J2EE Server"} 3
+	# HELP sap_j2ee_process_state AS Java node state, following the SAP state colors, the state text is in the state label
+	# TYPE sap_j2ee_process_state gauge
+	sap_j2ee_process_state{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",process_type="J2EE Server",state="Running"} 2
+	sap_j2ee_process_state{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server1",process_type="J2EE Server",state="Stopped"} 4
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_process_restarts", "sap_j2ee_process_state")
+	assert.NoError(t, err)
+}
+
+func TestThreadMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	v := mockWebService.GetMyClient().GetMyConfig().Viper
+	v.Set("j2ee_thread_long_running_threshold", "10m")
+	v.Set("j2ee_thread_long_running_loki", true)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+
+	now := time.Now().UTC()
+	threadList := &sapcontrol.J2EEGetThreadListResponse{
+		Threads: []*sapcontrol.J2EEThread{
+			{Processname: "server0", Name: "Application [1]", Pool: "Application", State: "Processing", Task: "HTTP request /irj/portal", TaskupdateTime: now.Add(-time.Hour).Format("2006 01 02 15:04:05")},
+			{Processname: "server0", Name: "Application [2]", Pool: "Application", State: "Processing", Task: "HTTP request /nwa", TaskupdateTime: now.Format("2006 01 02 15:04:05")},
+			{Processname: "server0", Name: "Application [3]", Pool: "Application", State: "Waiting for task"},
+			{Processname: "server0", Name: "System [1]", Pool: "System", State: "Waiting for task"},
+		},
+	}
+	mockWebService.EXPECT().J2EEGetThreadList(gomock.Any(), "http://sapjp1ci:50213").Return(threadList, nil).Times(2)
+	expectJ2EEInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_threads AS Java thread counts by pool and state
+	# TYPE sap_j2ee_threads gauge
+	sap_j2ee_threads{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",pool="Application",process="server0",state="Processing"} 2
+	sap_j2ee_threads{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",pool="Application",process="server0",state="Waiting for task"} 1
+	sap_j2ee_threads{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",pool="System",process="server0",state="Waiting for task"} 1
+	# HELP sap_j2ee_threads_long_running AS Java threads running their current task longer than j2ee_thread_long_running_threshold
+	# TYPE sap_j2ee_threads_long_running gauge
+	sap_j2ee_threads_long_running{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",pool="Application",process="server0"} 1
+	sap_j2ee_threads_long_running{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",pool="System",process="server0"} 0
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_threads", "sap_j2ee_threads_long_running")
+	assert.NoError(t, err)
+	testutil.CollectAndCount(collector)
+
+	// the long-runner is pushed when it first appears only
+	entries := loki.Received()
+	assert.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "HTTP request /irj/portal", entry.Line)
+	assert.Equal(t, "Application [1]", entry.Labels["thread"])
+}
+
+func TestSessionMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().J2EEGetWebSessionList2(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetWebSessionList2Response{
+		Sessions: []*sapcontrol.J2EEWebSession2{
+			{Processname: "server0", AppName: "sap.com/irj", State: "active", ActiveRequests: 1, User: "JDOE"},
+			{Processname: "server0", AppName: "sap.com/irj", State: "active", ActiveRequests: 2, User: "MMUSTER"},
+			{Processname: "server0", AppName: "sap.com/tc~webadmin", State: "active", User: "ADMIN"},
+			{Processname: "server0", AppName: "sap.com/irj", State: "invalidated", User: "JDOE"},
+		},
+	}, nil)
+	mockWebService.EXPECT().J2EEGetEJBSessionList(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetEJBSessionListResponse{
+		Sessions: []*sapcontrol.J2EEEJBSession{
+			{Processname: "server0", Application: "sap.com/irj", State: "active", User: "JDOE"},
+			{Processname: "server0", Application: "sap.com/irj", State: "active", User: "MMUSTER"},
+		},
+	}, nil)
+	mockWebService.EXPECT().J2EEGetSessionList(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetSessionListResponse{
+		Sessions: []*sapcontrol.J2EESession{
+			{Processname: "server0"},
+			{Processname: "server1"},
+		},
+	}, nil)
+	expectJ2EEInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_ejb_sessions AS Java EJB session counts by application and state
+	# TYPE sap_j2ee_ejb_sessions gauge
+	sap_j2ee_ejb_sessions{SID="JP1",application="sap.com/irj",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",state="active"} 2
+	# HELP sap_j2ee_sessions AS Java user session counts
+	# TYPE sap_j2ee_sessions gauge
+	sap_j2ee_sessions{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 1
+	sap_j2ee_sessions{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server1"} 1
+	# HELP sap_j2ee_web_sessions AS Java web session counts by application and state
+	# TYPE sap_j2ee_web_sessions gauge
+	sap_j2ee_web_sessions{SID="JP1",application="sap.com/irj",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",state="active"} 2
+	sap_j2ee_web_sessions{SID="JP1",application="sap.com/irj",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",state="invalidated"} 1
+	sap_j2ee_web_sessions{SID="JP1",application="sap.com/tc~webadmin",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",state="active"} 1
+	# HELP sap_j2ee_web_sessions_active_requests Requests being processed in AS Java web sessions
+	# TYPE sap_j2ee_web_sessions_active_requests gauge
+	sap_j2ee_web_sessions_active_requests{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 3
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_ejb_sessions", "sap_j2ee_sessions", "sap_j2ee_web_sessions", "sap_j2ee_web_sessions_active_requests")
+	assert.NoError(t, err)
+}
+
+func TestCacheAndSharedTableMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().J2EEGetCacheStatistic(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetCacheStatisticResponse{
+		Caches: []*sapcontrol.J2EECache{
+			{Processname: "server0", Cachename: "UME_CACHE", Type: "LOCAL", Size: 4096, CachedObjects: 10, UsedObjects: 4, Gets: 100, Hits: 90, Dispstatus: sapcontrol.STATECOLOR_GREEN},
+		},
+	}, nil)
+	mockWebService.EXPECT().J2EEGetSharedTableInfo(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetSharedTableInfoResponse{
+		Tables: []*sapcontrol.J2EESharedTableInfo{
+			{Table: "Sessions", Used: 120, Peak: 300, Limit: 1000, Dispstatus: sapcontrol.STATECOLOR_GREEN},
+		},
+	}, nil)
+	expectJ2EEInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_cache_hits Get requests served by the AS Java cache region
+	# TYPE sap_j2ee_cache_hits counter
+	sap_j2ee_cache_hits{SID="JP1",cache="UME_CACHE",cache_type="LOCAL",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 90
+	# HELP sap_j2ee_cache_misses Get requests not served by the AS Java cache region
+	# TYPE sap_j2ee_cache_misses counter
+	sap_j2ee_cache_misses{SID="JP1",cache="UME_CACHE",cache_type="LOCAL",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0"} 10
+	# HELP sap_j2ee_cache_objects Objects in the AS Java cache region, by usage
+	# TYPE sap_j2ee_cache_objects gauge
+	sap_j2ee_cache_objects{SID="JP1",cache="UME_CACHE",cache_type="LOCAL",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",usage="cached"} 10
+	sap_j2ee_cache_objects{SID="JP1",cache="UME_CACHE",cache_type="LOCAL",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",process="server0",usage="used"} 4
+	# HELP sap_j2ee_shared_table_used Used entries of the AS Java shared table
+	# TYPE sap_j2ee_shared_table_used gauge
+	sap_j2ee_shared_table_used{SID="JP1",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",table="Sessions"} 120
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_cache_hits", "sap_j2ee_cache_misses", "sap_j2ee_cache_objects", "sap_j2ee_shared_table_used")
+	assert.NoError(t, err)
+}
+
+func TestClusterAndComponentMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().J2EEGetClusterMsgList(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetClusterMsgListResponse{
+		Messages: []*sapcontrol.J2EEClusterMsg{
+			{Service: "session", Id: "105", Count: 42, Length: 8400, Maxlength: 512, Countp2pmsg: 30, Countp2prequest: 6, Countp2preply: 4, Countbroadcastmsg: 2},
+		},
+	}, nil)
+	mockWebService.EXPECT().J2EEGetComponentList(gomock.Any(), "http://sapjp1ci:50213").Return(&sapcontrol.J2EEGetComponentListResponse{
+		Components: []*sapcontrol.J2EEComponentInfo{
+			{Type: "service", Name: "http", Startupmode: "always", Status: "started", Expectedstatus: "started", Dispstatus: sapcontrol.STATECOLOR_GREEN},
+			{Type: "application", Name: "sap.com/tc~sec~ume~wd~umeadmin", Startupmode: "lazy", Status: "failed", Expectedstatus: "started", Dispstatus: sapcontrol.STATECOLOR_RED},
+			{Type: "application", Name: "tc~monitoring~systeminfo", Startupmode: "manual", Status: "stopped", Expectedstatus: "started", Dispstatus: sapcontrol.STATECOLOR_GRAY},
+		},
+	}, nil)
+	expectJ2EEInstances(mockWebService)
+
+	expectedMetrics := `
+	# HELP sap_j2ee_cluster_messages Messages exchanged by the AS Java cluster service
+	# TYPE sap_j2ee_cluster_messages counter
+	sap_j2ee_cluster_messages{SID="JP1",id="105",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",service="session"} 42
+	# HELP sap_j2ee_cluster_p2p_messages Point-to-point messages exchanged by the AS Java cluster service, by message type
+	# TYPE sap_j2ee_cluster_p2p_messages counter
+	sap_j2ee_cluster_p2p_messages{SID="JP1",id="105",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",message_type="message",service="session"} 30
+	sap_j2ee_cluster_p2p_messages{SID="JP1",id="105",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",message_type="reply",service="session"} 4
+	sap_j2ee_cluster_p2p_messages{SID="JP1",id="105",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",message_type="request",service="session"} 6
+	# HELP sap_j2ee_component_info AS Java service or application status as reported by the AS Java, the value is always 1
+	# TYPE sap_j2ee_component_info gauge
+	sap_j2ee_component_info{SID="JP1",component="http",component_type="service",expected_status="started",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",startup_mode="always",status="started"} 1
+	sap_j2ee_component_info{SID="JP1",component="sap.com/tc~sec~ume~wd~umeadmin",component_type="application",expected_status="started",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",startup_mode="lazy",status="failed"} 1
+	sap_j2ee_component_info{SID="JP1",component="tc~monitoring~systeminfo",component_type="application",expected_status="started",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2",startup_mode="manual",status="stopped"} 1
+	# HELP sap_j2ee_component_status AS Java service or application status: 1 started, 0 stopped, -1 failed
+	# TYPE sap_j2ee_component_status gauge
+	sap_j2ee_component_status{SID="JP1",component="http",component_type="service",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2"} 1
+	sap_j2ee_component_status{SID="JP1",component="sap.com/tc~sec~ume~wd~umeadmin",component_type="application",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2"} -1
+	sap_j2ee_component_status{SID="JP1",component="tc~monitoring~systeminfo",component_type="application",instance_hostname="sapjp1ci",instance_name="J02",instance_number="2"} 0
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_j2ee_cluster_messages", "sap_j2ee_cluster_p2p_messages", "sap_j2ee_component_status", "sap_j2ee_component_info")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+package j2ee
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+func (c *j2eeCollector) setProcessDescriptors() {
+	processLabels := []string{"process", "process_type", "instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("process_state", "AS Java node state, following the SAP state colors, the state text is in the state label",
+		append([]string{"state"}, processLabels...))
+	c.SetDescriptor("process_restarts", "AS Java node restarts by jcontrol", processLabels)
+	c.SetDescriptor("process_errors", "AS Java node errors counted by jcontrol", processLabels)
+}
+
+func (c *j2eeCollector) recordProcesses(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordProcesses collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordProcesses")
+	}
+
+	for _, instance := range instances {
+
+		processList, err := c.webService.J2EEGetProcessList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordProcesses: %v", err)
+			continue
+		}
+
+		for _, process := range processList.Processes {
+			labels := append([]string{process.Name, process.Type}, commonLabels(instance)...)
+
+			ch <- c.MakeCounterMetric("process_restarts", float64(process.RestartCount), labels...)
+			ch <- c.MakeCounterMetric("process_errors", float64(process.ErrorCount), labels...)
+
+			state, err := sapcontrol.J2EEStateToFloat(process.State)
+			if err != nil {
+				log.Warnf("recordProcesses: process %s state %q: %s", process.Name, process.State, err)
+				continue
+			}
+			ch <- c.MakeGaugeMetric("process_state", state, append([]string{process.Statetext}, labels...)...)
+		}
+	}
+	return nil
+}
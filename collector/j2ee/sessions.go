@@ -0,0 +1,125 @@
+package j2ee
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// the session lists are aggregated, user names never become label values
+func (c *j2eeCollector) setSessionDescriptors() {
+	c.SetDescriptor("web_sessions", "AS Java web session counts by application and state",
+		[]string{"process", "application", "state", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("web_sessions_active_requests", "Requests being processed in AS Java web sessions",
+		[]string{"process", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("ejb_sessions", "AS Java EJB session counts by application and state",
+		[]string{"process", "application", "state", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("sessions", "AS Java user session counts",
+		[]string{"process", "instance_name", "instance_number", "SID", "instance_hostname"})
+}
+
+func (c *j2eeCollector) recordWebSessions(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordWebSessions collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordWebSessions")
+	}
+
+	type sessionGroup struct {
+		process     string
+		application string
+		state       string
+	}
+
+	for _, instance := range instances {
+
+		sessionList, err := c.webService.J2EEGetWebSessionList2(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordWebSessions: %v", err)
+			continue
+		}
+
+		sessions := make(map[sessionGroup]int)
+		activeRequests := make(map[string]int32)
+		for _, session := range sessionList.Sessions {
+			sessions[sessionGroup{session.Processname, session.AppName, session.State}]++
+			activeRequests[session.Processname] += session.ActiveRequests
+		}
+
+		for group, count := range sessions {
+			ch <- c.MakeGaugeMetric("web_sessions", float64(count), append([]string{group.process, group.application, group.state}, commonLabels(instance)...)...)
+		}
+		for process, count := range activeRequests {
+			ch <- c.MakeGaugeMetric("web_sessions_active_requests", float64(count), append([]string{process}, commonLabels(instance)...)...)
+		}
+	}
+	return nil
+}
+
+func (c *j2eeCollector) recordEJBSessions(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordEJBSessions collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordEJBSessions")
+	}
+
+	type sessionGroup struct {
+		process     string
+		application string
+		state       string
+	}
+
+	for _, instance := range instances {
+
+		sessionList, err := c.webService.J2EEGetEJBSessionList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordEJBSessions: %v", err)
+			continue
+		}
+
+		sessions := make(map[sessionGroup]int)
+		for _, session := range sessionList.Sessions {
+			sessions[sessionGroup{session.Processname, session.Application, session.State}]++
+		}
+
+		for group, count := range sessions {
+			ch <- c.MakeGaugeMetric("ejb_sessions", float64(count), append([]string{group.process, group.application, group.state}, commonLabels(instance)...)...)
+		}
+	}
+	return nil
+}
+
+func (c *j2eeCollector) recordSessions(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordSessions collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordSessions")
+	}
+
+	for _, instance := range instances {
+
+		sessionList, err := c.webService.J2EEGetSessionList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordSessions: %v", err)
+			continue
+		}
+
+		sessions := make(map[string]int)
+		for _, session := range sessionList.Sessions {
+			sessions[session.Processname]++
+		}
+
+		for process, count := range sessions {
+			ch <- c.MakeGaugeMetric("sessions", float64(count), append([]string{process}, commonLabels(instance)...)...)
+		}
+	}
+	return nil
+}
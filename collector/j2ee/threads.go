@@ -0,0 +1,137 @@
+package j2ee
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/promtail-client/promtail"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// identifies a task run by a thread, a new task of the same thread is a different long-runner
+type threadTask struct {
+	endpoint       string
+	process        string
+	thread         string
+	task           string
+	taskupdateTime string
+}
+
+func (c *j2eeCollector) setThreadDescriptors() {
+	c.SetDescriptor("threads", "AS Java thread counts by pool and state",
+		[]string{"process", "pool", "state", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("threads_long_running", "AS Java threads running their current task longer than j2ee_thread_long_running_threshold",
+		[]string{"process", "pool", "instance_name", "instance_number", "SID", "instance_hostname"})
+}
+
+func (c *j2eeCollector) recordThreads(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordThreads collecting")
+
+	instances, err := c.j2eeInstances(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordThreads")
+	}
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	threshold := v.GetDuration("j2ee_thread_long_running_threshold")
+	loki_client := c.webService.GetLokiClient()
+	if !v.GetBool("j2ee_thread_long_running_loki") {
+		loki_client = nil
+	}
+	loc := c.webService.GetMyClient().GetTimeLocation()
+
+	type threadGroup struct {
+		process string
+		pool    string
+		state   string
+	}
+
+	for _, instance := range instances {
+
+		threadList, err := c.webService.J2EEGetThreadList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordThreads: %v", err)
+			continue
+		}
+
+		threads := make(map[threadGroup]int)
+		longRunning := make(map[threadGroup]int)
+		longRunningTasks := make(map[threadTask]*sapcontrol.J2EEThread)
+
+		for _, thread := range threadList.Threads {
+			threads[threadGroup{thread.Processname, thread.Pool, thread.State}]++
+
+			if thread.Task == "" {
+				continue
+			}
+			taskUpdate, err := sapcontrol.ParseSAPTime(thread.TaskupdateTime, loc)
+			if err != nil {
+				log.Debugf("recordThreads: thread %s task update time: %s", thread.Name, err)
+				continue
+			}
+			if time.Since(taskUpdate) > threshold {
+				longRunning[threadGroup{thread.Processname, thread.Pool, ""}]++
+				longRunningTasks[threadTask{instance.Endpoint, thread.Processname, thread.Name, thread.Task, thread.TaskupdateTime}] = thread
+			}
+		}
+
+		for group, count := range threads {
+			ch <- c.MakeGaugeMetric("threads", float64(count), append([]string{group.process, group.pool, group.state}, commonLabels(instance)...)...)
+		}
+		// every pool gets a series, so that it drops to 0 when the long-runners finish
+		for group := range threads {
+			group.state = ""
+			if _, ok := longRunning[group]; !ok {
+				longRunning[group] = 0
+			}
+		}
+		for group, count := range longRunning {
+			ch <- c.MakeGaugeMetric("threads_long_running", float64(count), append([]string{group.process, group.pool}, commonLabels(instance)...)...)
+		}
+
+		c.pushNewLongRunners(instance, longRunningTasks, loki_client)
+	}
+	return nil
+}
+
+// remembers the long-running tasks of the instance, and pushes the new ones to Loki
+func (c *j2eeCollector) pushNewLongRunners(instance sapcontrol.InstanceInfo, tasks map[threadTask]*sapcontrol.J2EEThread, loki_client promtail.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for task := range c.longRunningTasks {
+		if _, ok := tasks[task]; !ok && task.endpoint == instance.Endpoint {
+			delete(c.longRunningTasks, task)
+		}
+	}
+	for task, thread := range tasks {
+		if c.longRunningTasks[task] {
+			continue
+		}
+		c.longRunningTasks[task] = true
+		if loki_client == nil {
+			continue
+		}
+		labels := commonLabels(instance)
+		loki_client.Single() <- &promtail.SingleEntry{
+			Labels: map[string]string{
+				"level":             "warning",
+				"process":           thread.Processname,
+				"pool":              thread.Pool,
+				"thread":            thread.Name,
+				"user":              thread.User,
+				"instance_name":     labels[0],
+				"instance_number":   labels[1],
+				"SID":               labels[2],
+				"instance_hostname": labels[3],
+			},
+			Ts:   time.Now(),
+			Line: thread.Task,
+		}
+	}
+}
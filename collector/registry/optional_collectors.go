@@ -9,6 +9,14 @@ import (
 	"github.com/vgrusdev/sap_system_exporter/collector/alerts"
 	"github.com/vgrusdev/sap_system_exporter/collector/dispatcher"
 	"github.com/vgrusdev/sap_system_exporter/collector/enqueue_server"
+	"github.com/vgrusdev/sap_system_exporter/collector/ha"
+	"github.com/vgrusdev/sap_system_exporter/collector/icm"
+	"github.com/vgrusdev/sap_system_exporter/collector/inventory"
+	"github.com/vgrusdev/sap_system_exporter/collector/j2ee"
+	"github.com/vgrusdev/sap_system_exporter/collector/rfc"
+	"github.com/vgrusdev/sap_system_exporter/collector/syslog"
+	"github.com/vgrusdev/sap_system_exporter/collector/version"
+	"github.com/vgrusdev/sap_system_exporter/collector/webdispatcher"
 	"github.com/vgrusdev/sap_system_exporter/collector/workprocess"
 	"github.com/vgrusdev/sap_system_exporter/internal/config"
 	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
@@ -66,5 +74,97 @@ func RegisterOptionalCollectors(webService sapcontrol.WebService) error {
 	} else {
 		log.Debug("Alerts optional collector is not registered")
 	}
+	if v.GetBool("collect_icm") {
+		icmCollector, err := icm.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: ICM")
+		} else {
+			prometheus.MustRegister(icmCollector)
+			log.Info("ICM optional collector registered")
+		}
+	} else {
+		log.Debug("ICM optional collector is not registered")
+	}
+	if v.GetBool("collect_webdispatcher") {
+		webDispatcherCollector, err := webdispatcher.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: Web Dispatcher")
+		} else {
+			prometheus.MustRegister(webDispatcherCollector)
+			log.Info("Web Dispatcher optional collector registered")
+		}
+	} else {
+		log.Debug("Web Dispatcher optional collector is not registered")
+	}
+	if v.GetBool("collect_ha") {
+		haCollector, err := ha.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: HA")
+		} else {
+			prometheus.MustRegister(haCollector)
+			log.Info("HA optional collector registered")
+		}
+	} else {
+		log.Debug("HA optional collector is not registered")
+	}
+	if v.GetBool("collect_syslog") {
+		if webService.GetLokiClient() == nil {
+			log.Warn("Syslog optional collector is not registered, it requires loki_url")
+		} else {
+			syslogCollector, err := syslog.NewCollector(webService)
+			if err != nil {
+				return errors.Wrap(err, "RegisterOptionalCollectors: Syslog")
+			} else {
+				prometheus.MustRegister(syslogCollector)
+				log.Info("Syslog optional collector registered")
+			}
+		}
+	} else {
+		log.Debug("Syslog optional collector is not registered")
+	}
+	if v.GetBool("collect_version") {
+		versionCollector, err := version.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: Version")
+		} else {
+			prometheus.MustRegister(versionCollector)
+			log.Info("Version optional collector registered")
+		}
+	} else {
+		log.Debug("Version optional collector is not registered")
+	}
+	if v.GetBool("collect_j2ee") {
+		j2eeCollector, err := j2ee.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: J2EE")
+		} else {
+			prometheus.MustRegister(j2eeCollector)
+			log.Info("J2EE optional collector registered")
+		}
+	} else {
+		log.Debug("J2EE optional collector is not registered")
+	}
+	if v.GetBool("collect_inventory") {
+		inventoryCollector, err := inventory.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: Inventory")
+		} else {
+			prometheus.MustRegister(inventoryCollector)
+			log.Info("Inventory optional collector registered")
+		}
+	} else {
+		log.Debug("Inventory optional collector is not registered")
+	}
+	if v.GetBool("collect_rfc") {
+		rfcCollector, err := rfc.NewCollector(webService)
+		if err != nil {
+			return errors.Wrap(err, "RegisterOptionalCollectors: RFC")
+		} else {
+			prometheus.MustRegister(rfcCollector)
+			log.Info("RFC optional collector registered")
+		}
+	} else {
+		log.Debug("RFC optional collector is not registered")
+	}
 	return nil
 }
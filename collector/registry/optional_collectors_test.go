@@ -1,148 +1,62 @@
 package registry
 
 import (
-	"bytes"
+	"io"
 	"os"
 	"testing"
 
-	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
-	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
-	"github.com/golang/mock/gomock"
-
-	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
 )
 
+// the loggers write to the standard output
 func captureOutput(f func()) string {
-	var buf bytes.Buffer
-	log.SetOutput(&buf)
+	stdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
 	f()
-	log.SetOutput(os.Stderr)
-	return buf.String()
+	w.Close()
+	os.Stdout = stdout
+	output, _ := io.ReadAll(r)
+	return string(output)
 }
 
 func TestActivationDispatcherOutput(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-
-	mockWebService.EXPECT().GetProcessList().Return(&sapcontrol.GetProcessListResponse{
-		Processes: []*sapcontrol.OSProcess{
-			{
-				Name:        "enserver",
-				Description: "foobar",
-				Dispstatus:  sapcontrol.STATECOLOR_GREEN,
-				Textstatus:  "Running",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30787,
-			},
-			{
-				// activate the collector
-				Name:        "disp+work",
-				Description: "foobar2",
-				Dispstatus:  sapcontrol.STATECOLOR_YELLOW,
-				Textstatus:  "Stopping",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30786,
-			},
-			{
-				// activate the collector (on windows)
-				Name:        "disp+work.EXE",
-				Description: "foobar2",
-				Dispstatus:  sapcontrol.STATECOLOR_YELLOW,
-				Textstatus:  "Stopping",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30785,
-			},
-		},
-	}, nil)
+	mockWebService := fixtures.NewMockWebService(ctrl, map[string]interface{}{"collect_dispatcher": true})
 
 	output := captureOutput(func() {
-		RegisterOptionalCollectors(mockWebService)
+		err := RegisterOptionalCollectors(mockWebService)
+		assert.NoError(t, err)
 	})
 	assert.Contains(t, output, "Dispatcher optional collector registered")
+	assert.NotContains(t, output, "Enqueue Server optional collector registered")
 }
 
 func TestActivationEnqueueServerOutput(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-
-	mockWebService.EXPECT().GetProcessList().Return(&sapcontrol.GetProcessListResponse{
-		Processes: []*sapcontrol.OSProcess{
-			{
-				Name:        "enserver",
-				Description: "foobar",
-				Dispstatus:  sapcontrol.STATECOLOR_GREEN,
-				Textstatus:  "Running",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30787,
-			},
-			{
-				// activate the collector
-				Name:        "msg_server",
-				Description: "foobar2",
-				Dispstatus:  sapcontrol.STATECOLOR_YELLOW,
-				Textstatus:  "Stopping",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30786,
-			},
-			{
-				// activate the collector (On Windows)
-				Name:        "msg_server.EXE",
-				Description: "foobar2",
-				Dispstatus:  sapcontrol.STATECOLOR_YELLOW,
-				Textstatus:  "Stopping",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30785,
-			},
-		},
-	}, nil)
+	mockWebService := fixtures.NewMockWebService(ctrl, map[string]interface{}{"collect_enqueueserver": true})
 
 	output := captureOutput(func() {
-		RegisterOptionalCollectors(mockWebService)
+		err := RegisterOptionalCollectors(mockWebService)
+		assert.NoError(t, err)
 	})
 	assert.Contains(t, output, "Enqueue Server optional collector registered")
-
+	assert.NotContains(t, output, "Dispatcher optional collector registered")
 }
 
 func TestNoActivation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-
-	mockWebService.EXPECT().GetProcessList().Return(&sapcontrol.GetProcessListResponse{
-		Processes: []*sapcontrol.OSProcess{
-			{
-				Name:        "bro",
-				Description: "afake",
-				Dispstatus:  sapcontrol.STATECOLOR_GREEN,
-				Textstatus:  "Running",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30787,
-			},
-			{
-				// activate the collector
-				Name:        "foa",
-				Description: "foobar2",
-				Dispstatus:  sapcontrol.STATECOLOR_YELLOW,
-				Textstatus:  "Stopping",
-				Starttime:   "",
-				Elapsedtime: "",
-				Pid:         30786,
-			},
-		},
-	}, nil)
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
 
 	output := captureOutput(func() {
-		RegisterOptionalCollectors(mockWebService)
+		err := RegisterOptionalCollectors(mockWebService)
+		assert.NoError(t, err)
 	})
 	assert.NotContains(t, output, "Enqueue Server optional collector registered")
 	assert.NotContains(t, output, "Dispatcher optional collector registered")
@@ -0,0 +1,152 @@
+package rfc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// result of the last ABAPCheckRFCDestinations run
+type rfcCheck struct {
+	sid          string
+	destinations []string
+	success      bool
+	duration     time.Duration
+	timestamp    time.Time
+}
+
+type rfcCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+	mu         sync.Mutex
+	// set by the check goroutine, nil until the first check finished
+	lastCheck *rfcCheck
+}
+
+func NewCollector(webService sapcontrol.WebService) (*rfcCollector, error) {
+
+	c := &rfcCollector{
+		collector.NewDefaultCollector("abap"),
+		webService,
+		config.NewLogger("rfc"),
+		sync.Mutex{},
+		nil,
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.SetDescriptor("rfc_destination_spof", "RFC destination reported by ABAPCheckRFCDestinations as a single point of failure, the value is always 1",
+		[]string{"destination", "SID"})
+	c.SetDescriptor("rfc_destinations_check_success", "1 if the last ABAPCheckRFCDestinations run succeeded, 0 otherwise",
+		[]string{"SID"})
+	c.SetDescriptor("rfc_destinations_check_duration_seconds", "Duration of the last ABAPCheckRFCDestinations run",
+		[]string{"SID"})
+	c.SetDescriptor("rfc_destinations_check_timestamp_seconds", "Time of the last ABAPCheckRFCDestinations run",
+		[]string{"SID"})
+
+	// the connection tests can outlast a scrape, they run apart from Collect
+	go c.runChecks()
+
+	return c, nil
+}
+
+func (c *rfcCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting RFC destination metrics")
+
+	c.mu.Lock()
+	check := c.lastCheck
+	c.mu.Unlock()
+
+	if check == nil {
+		log.Debug("RFC Collector: no RFC destination check finished yet")
+		return
+	}
+
+	success := 0.0
+	if check.success {
+		success = 1
+		for _, destination := range check.destinations {
+			ch <- c.MakeGaugeMetric("rfc_destination_spof", 1, destination, check.sid)
+		}
+	}
+	ch <- c.MakeGaugeMetric("rfc_destinations_check_success", success, check.sid)
+	ch <- c.MakeGaugeMetric("rfc_destinations_check_duration_seconds", check.duration.Seconds(), check.sid)
+	ch <- c.MakeGaugeMetric("rfc_destinations_check_timestamp_seconds", float64(check.timestamp.Unix()), check.sid)
+}
+
+// Runs the check every rfc_check_interval, so that the scrapes do not repeat the connection tests.
+// A failed check is repeated after cache_ttl.
+func (c *rfcCollector) runChecks() {
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+
+	for {
+		retry := v.GetDuration("cache_ttl")
+		if retry == 0 {
+			retry = 30 * time.Second
+		}
+		interval := v.GetDuration("rfc_check_interval")
+		if interval == 0 {
+			interval = time.Hour
+		}
+
+		check, err := c.checkRFCDestinations()
+		if err != nil {
+			// no ABAP instance, retry with the next instance list refresh
+			c.logger.Errorf("runChecks: %v", err)
+			interval = retry
+		} else {
+			if !check.success {
+				interval = retry
+			}
+			c.mu.Lock()
+			c.lastCheck = check
+			c.mu.Unlock()
+		}
+		time.Sleep(interval)
+	}
+}
+
+// runs the check on the first ABAP instance, the connection tests are bound to rfc_check_timeout
+func (c *rfcCollector) checkRFCDestinations() (*rfcCheck, error) {
+	log := c.logger
+	timeout := c.webService.GetMyClient().GetMyConfig().Viper.GetDuration("rfc_check_timeout")
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkRFCDestinations")
+	}
+
+	for _, instance := range instanceInfo {
+
+		if !strings.Contains(strings.ToUpper(instance.Features), "ABAP") {
+			continue
+		}
+
+		check := &rfcCheck{sid: instance.SID, timestamp: time.Now()}
+		response, err := c.webService.ABAPCheckRFCDestinations(ctx, instance.Endpoint)
+		check.duration = time.Since(check.timestamp)
+		if err != nil {
+			log.Errorf("checkRFCDestinations: %v", err)
+			return check, nil
+		}
+		check.success = true
+		check.destinations = response.Destination
+		return check, nil
+	}
+	return nil, errors.New("checkRFCDestinations: no ABAP instance in the instance list")
+}
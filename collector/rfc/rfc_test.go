@@ -0,0 +1,174 @@
+package rfc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+func newMockWebService(ctrl *gomock.Controller) *mock_sapcontrol.MockWebService {
+	return newMockWebServiceWithSettings(ctrl, map[string]interface{}{"rfc_check_interval": "1h", "rfc_check_timeout": "5m"})
+}
+
+func newMockWebServiceWithSettings(ctrl *gomock.Controller, settings map[string]interface{}) *mock_sapcontrol.MockWebService {
+	mockWebService := fixtures.NewMockWebService(ctrl, settings)
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0, Features: "MESSAGESERVER|ENQUE"}, Name: "ASCS00", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil).AnyTimes()
+	return mockWebService
+}
+
+// the check runs in the background, waits for the scrape to export the expected metrics
+func assertEventuallyCollected(t *testing.T, collector *rfcCollector, expectedMetrics string, metricNames ...string) {
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics), metricNames...) == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPCheckRFCDestinationsResponse{}, nil).AnyTimes()
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestRFCDestinationsCheckedOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPCheckRFCDestinationsResponse{
+		Destination: []string{"BW_PROD", "SM_SOLMAN"},
+	}, nil).Times(1)
+
+	expectedMetrics := `
+	# HELP sap_abap_rfc_destination_spof RFC destination reported by ABAPCheckRFCDestinations as a single point of failure, the value is always 1
+	# TYPE sap_abap_rfc_destination_spof gauge
+	sap_abap_rfc_destination_spof{SID="HA1",destination="BW_PROD"} 1
+	sap_abap_rfc_destination_spof{SID="HA1",destination="SM_SOLMAN"} 1
+	# HELP sap_abap_rfc_destinations_check_success 1 if the last ABAPCheckRFCDestinations run succeeded, 0 otherwise
+	# TYPE sap_abap_rfc_destinations_check_success gauge
+	sap_abap_rfc_destinations_check_success{SID="HA1"} 1
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	metricNames := []string{"sap_abap_rfc_destination_spof", "sap_abap_rfc_destinations_check_success"}
+	assertEventuallyCollected(t, collector, expectedMetrics, metricNames...)
+	// the next scrape exports the same check
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics), metricNames...)
+	assert.NoError(t, err)
+}
+
+func TestRFCDestinationsCheckFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").Return(nil, errors.New("Permission denied")).Times(1)
+
+	expectedMetrics := `
+	# HELP sap_abap_rfc_destinations_check_success 1 if the last ABAPCheckRFCDestinations run succeeded, 0 otherwise
+	# TYPE sap_abap_rfc_destinations_check_success gauge
+	sap_abap_rfc_destinations_check_success{SID="HA1"} 0
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	metricNames := []string{"sap_abap_rfc_destination_spof", "sap_abap_rfc_destinations_check_success"}
+	assertEventuallyCollected(t, collector, expectedMetrics, metricNames...)
+	// the failed check is not repeated before cache_ttl
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics), metricNames...)
+	assert.NoError(t, err)
+}
+
+func TestRFCDestinationsCheckFailedRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebServiceWithSettings(ctrl, map[string]interface{}{"rfc_check_interval": "1h", "rfc_check_timeout": "5m", "cache_ttl": "10ms"})
+	gomock.InOrder(
+		mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").Return(nil, errors.New("Permission denied")),
+		mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPCheckRFCDestinationsResponse{}, nil),
+	)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	// the failed check is repeated after cache_ttl, not rfc_check_interval
+	assertEventuallyCollected(t, collector, `
+	# HELP sap_abap_rfc_destinations_check_success 1 if the last ABAPCheckRFCDestinations run succeeded, 0 otherwise
+	# TYPE sap_abap_rfc_destinations_check_success gauge
+	sap_abap_rfc_destinations_check_success{SID="HA1"} 1
+`, "sap_abap_rfc_destinations_check_success")
+}
+
+func TestRFCDestinationsCheckTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").DoAndReturn(
+		func(ctx context.Context, endpoint string) (*sapcontrol.ABAPCheckRFCDestinationsResponse, error) {
+			// bound by rfc_check_timeout, past the 5s scrape_timeout
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.True(t, time.Until(deadline) > time.Minute)
+			return &sapcontrol.ABAPCheckRFCDestinationsResponse{}, nil
+		})
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	assertEventuallyCollected(t, collector, `
+	# HELP sap_abap_rfc_destinations_check_success 1 if the last ABAPCheckRFCDestinations run succeeded, 0 otherwise
+	# TYPE sap_abap_rfc_destinations_check_success gauge
+	sap_abap_rfc_destinations_check_success{SID="HA1"} 1
+`, "sap_abap_rfc_destinations_check_success")
+}
+
+func TestRFCDestinationsCheckRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	running := make(chan struct{})
+	release := make(chan struct{})
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().ABAPCheckRFCDestinations(gomock.Any(), "http://sapha1pas:50113").DoAndReturn(
+		func(ctx context.Context, endpoint string) (*sapcontrol.ABAPCheckRFCDestinationsResponse, error) {
+			close(running)
+			<-release
+			return &sapcontrol.ABAPCheckRFCDestinationsResponse{}, nil
+		})
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+	<-running
+
+	// the scrape does not wait for the running check
+	assert.Equal(t, 0, testutil.CollectAndCount(collector))
+
+	close(release)
+	assertEventuallyCollected(t, collector, `
+	# HELP sap_abap_rfc_destinations_check_success 1 if the last ABAPCheckRFCDestinations run succeeded, 0 otherwise
+	# TYPE sap_abap_rfc_destinations_check_success gauge
+	sap_abap_rfc_destinations_check_success{SID="HA1"} 1
+`, "sap_abap_rfc_destinations_check_success")
+}
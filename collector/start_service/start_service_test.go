@@ -4,17 +4,18 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
-	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
-	"github.com/golang/mock/gomock"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
 )
 
 func TestNewCollector(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
 
 	_, err := NewCollector(mockWebService)
 
@@ -25,10 +26,13 @@ func TestProcessesMetric(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-	mockWebService.EXPECT().GetProcessList().Return(&sapcontrol.GetProcessListResponse{
-		Processes: []*sapcontrol.OSProcess{
-			{
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0}, Name: "ASCS", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), "http://sapha1as:50013").Return([]sapcontrol.ProcessInfo{
+		{
+			OSProcess: sapcontrol.OSProcess{
 				Name:        "enserver",
 				Description: "foobar",
 				Dispstatus:  sapcontrol.STATECOLOR_GREEN,
@@ -37,7 +41,9 @@ func TestProcessesMetric(t *testing.T) {
 				Elapsedtime: "",
 				Pid:         30787,
 			},
-			{
+		},
+		{
+			OSProcess: sapcontrol.OSProcess{
 				Name:        "msg_server",
 				Description: "foobar2",
 				Dispstatus:  sapcontrol.STATECOLOR_YELLOW,
@@ -48,19 +54,12 @@ func TestProcessesMetric(t *testing.T) {
 			},
 		},
 	}, nil)
-	mockWebService.EXPECT().GetSystemInstanceList().Return(&sapcontrol.GetSystemInstanceListResponse{}, nil)
-	mockWebService.EXPECT().GetCurrentInstance().Return(&sapcontrol.CurrentSapInstance{
-		SID:      "HA1",
-		Number:   0,
-		Name:     "ASCS",
-		Hostname: "sapha1as",
-	}, nil).AnyTimes()
 
 	expectedMetrics := `
 	# HELP sap_start_service_processes The processes started by the SAP Start Service
 	# TYPE sap_start_service_processes gauge
-	sap_start_service_processes{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",name="enserver",pid="30787",status="Running"} 2
-	sap_start_service_processes{SID="HA1",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",name="msg_server",pid="30786",status="Stopping"} 3
+	sap_start_service_processes{SID="HA1",description="foobar",elapsedtime="",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",name="enserver",pid="30787",proc_dispstatus="SAPControl-GREEN",starttime="",status="Running"} 2
+	sap_start_service_processes{SID="HA1",description="foobar2",elapsedtime="",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",name="msg_server",pid="30786",proc_dispstatus="SAPControl-YELLOW",starttime="",status="Stopping"} 3
 	`
 
 	var err error
@@ -75,10 +74,10 @@ func TestInstancesMetric(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
-	mockWebService.EXPECT().GetSystemInstanceList().Return(&sapcontrol.GetSystemInstanceListResponse{
-		Instances: []*sapcontrol.SAPInstance{
-			{
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{
+			SAPInstance: sapcontrol.SAPInstance{
 				Hostname:      "sapha1as",
 				InstanceNr:    0,
 				HttpPort:      50013,
@@ -87,7 +86,13 @@ func TestInstancesMetric(t *testing.T) {
 				Features:      "MESSAGESERVER|ENQUE",
 				Dispstatus:    sapcontrol.STATECOLOR_GREEN,
 			},
-			{
+			Name:     "ASCS00",
+			SID:      "HA1",
+			Endpoint: "http://sapha1as:50013",
+			Status:   2,
+		},
+		{
+			SAPInstance: sapcontrol.SAPInstance{
 				Hostname:      "sapha1er",
 				InstanceNr:    10,
 				HttpPort:      51013,
@@ -96,20 +101,19 @@ func TestInstancesMetric(t *testing.T) {
 				Features:      "ENQREP",
 				Dispstatus:    sapcontrol.STATECOLOR_GREEN,
 			},
+			Name:     "ERS10",
+			SID:      "HA1",
+			Endpoint: "http://sapha1er:51013",
+			Status:   2,
 		},
-	}, nil)
-	mockWebService.EXPECT().GetProcessList().Return(&sapcontrol.GetProcessListResponse{}, nil)
-	mockWebService.EXPECT().GetCurrentInstance().Return(&sapcontrol.CurrentSapInstance{
-		SID:      "HA1",
-		Number:   0,
-		Name:     "ASCS",
-		Hostname: "sapha1as",
 	}, nil).AnyTimes()
+	mockWebService.EXPECT().GetCachedProcessList(gomock.Any(), gomock.Any()).Return([]sapcontrol.ProcessInfo{}, nil).AnyTimes()
 
 	expectedMetrics := `
 	# HELP sap_start_service_instances The SAP instances in the context of the whole SAP system
 	# TYPE sap_start_service_instances gauge
-    sap_start_service_instances{SID="HA1",features="MESSAGESERVER|ENQUE",instance_hostname="sapha1as",instance_name="ASCS",instance_number="0",start_priority="1"} 2
+	sap_start_service_instances{SID="HA1",dispstatus="SAPControl-GREEN",features="ENQREP",instance_hostname="sapha1er",instance_name="ERS10",instance_number="10",start_priority="0.5"} 2
+	sap_start_service_instances{SID="HA1",dispstatus="SAPControl-GREEN",features="MESSAGESERVER|ENQUE",instance_hostname="sapha1as",instance_name="ASCS00",instance_number="0",start_priority="1"} 2
 	`
 
 	var err error
@@ -0,0 +1,173 @@
+package syslog
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/promtail-client/promtail"
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// last forwarded position in the syslog of an instance
+type watermark struct {
+	ts time.Time
+	// entries with timestamp ts that were already forwarded, the syslog has a one second resolution
+	entries   map[sapcontrol.SyslogEntry]bool
+	forwarded float64
+}
+
+type syslogCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+	mu         sync.Mutex
+	watermarks map[string]*watermark
+}
+
+func NewCollector(webService sapcontrol.WebService) (*syslogCollector, error) {
+
+	c := &syslogCollector{
+		collector.NewDefaultCollector("syslog"),
+		webService,
+		config.NewLogger("syslog"),
+		sync.Mutex{},
+		make(map[string]*watermark),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.SetDescriptor("entries_forwarded", "ABAP syslog entries forwarded to Loki",
+		[]string{"instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("last_entry_timestamp_seconds", "Timestamp of the last ABAP syslog entry forwarded to Loki",
+		[]string{"instance_name", "instance_number", "SID", "instance_hostname"})
+
+	return c, nil
+}
+
+func (c *syslogCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting Syslog")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := c.recordSyslog(ctx, ch)
+	if err != nil {
+		log.Errorf("Syslog Collector: %s", err)
+	}
+}
+
+func (c *syslogCollector) recordSyslog(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordSyslog start")
+
+	loki_client := c.webService.GetLokiClient()
+	if loki_client == nil {
+		return errors.New("recordSyslog: Loki client is not configured")
+	}
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordSyslog")
+	}
+	log.Debugf("recordSyslog: Instances in the list: %d", len(instanceInfo))
+
+	samples_max_age := c.webService.GetMyClient().GetMyConfig().Viper.GetDuration("syslog_samples_max_age")
+	timeLocation := loki_client.GetLocation()
+
+	// scrapes may overlap, the watermarks must only be moved by one of them at a time
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, instance := range instanceInfo {
+
+		if !strings.Contains(strings.ToUpper(instance.Features), "ABAP") {
+			continue
+		}
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		syslog, err := c.webService.ABAPReadSyslog(ctx, instance.Endpoint)
+		if err != nil {
+			log.Warnf("ABAPReadSyslog: %s", err)
+			continue
+		}
+
+		mark, ok := c.watermarks[instance.Endpoint]
+		if !ok {
+			// first read of the instance, only the entries within syslog_samples_max_age are forwarded
+			mark = &watermark{entries: make(map[sapcontrol.SyslogEntry]bool)}
+			if samples_max_age >= 0 {
+				mark.ts = time.Now().Add(-samples_max_age)
+			}
+			c.watermarks[instance.Endpoint] = mark
+		}
+
+		// the entries are not ordered by time, each one is compared with the watermark of the previous reads,
+		// the watermark is moved to the newest entry once the whole log is read
+		lastTs, lastEntries := mark.ts, mark.entries
+		num_sent_to_loki := 0
+		for _, entry := range syslog.Log {
+
+			t, err := sapcontrol.ParseSAPTime(entry.Time, timeLocation)
+			if err != nil {
+				log.Warnf("Syslog entry Time parsing: %s", err)
+				continue
+			}
+			if t.Before(mark.ts) || (t.Equal(mark.ts) && mark.entries[*entry]) {
+				continue
+			}
+			if t.After(lastTs) {
+				lastTs = t
+				lastEntries = make(map[sapcontrol.SyslogEntry]bool)
+			}
+			if t.Equal(lastTs) {
+				lastEntries[*entry] = true
+			}
+
+			level, _ := sapcontrol.StateColorToLevel(entry.Severity)
+			labelSet := map[string]string{
+				"level":             level,
+				"client":            entry.Client,
+				"user":              entry.User,
+				"tcode":             entry.Tcode,
+				"message_number":    entry.MNo,
+				"instance_name":     instance.Name,
+				"instance_number":   strconv.Itoa(int(instance.InstanceNr)),
+				"SID":               instance.SID,
+				"instance_hostname": instance.Hostname,
+			}
+			loki_client.Single() <- &promtail.SingleEntry{
+				Labels: labelSet,
+				Ts:     t,
+				Line:   entry.Text,
+			}
+			num_sent_to_loki += 1
+		}
+		mark.ts, mark.entries = lastTs, lastEntries
+		mark.forwarded += float64(num_sent_to_loki)
+		log.Debugf("Syslog entries sent to loki: %d", num_sent_to_loki)
+
+		ch <- c.MakeCounterMetric("entries_forwarded", mark.forwarded, commonLabels...)
+		if len(mark.entries) > 0 {
+			ch <- c.MakeGaugeMetric("last_entry_timestamp_seconds", float64(mark.ts.Unix()), commonLabels...)
+		}
+	}
+	log.Debug("recordSyslog success")
+	return nil
+}
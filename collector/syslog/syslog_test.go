@@ -0,0 +1,121 @@
+package syslog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+func newMockWebService(ctrl *gomock.Controller) *mock_sapcontrol.MockWebService {
+	return fixtures.NewMockWebService(ctrl, map[string]interface{}{"syslog_samples_max_age": "-1s"})
+}
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestSyslogForwardedOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0, Features: "MESSAGESERVER|ENQUE"}, Name: "ASCS00", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+	}, nil).AnyTimes()
+
+	first := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:00", Client: "000", User: "DDIC", Tcode: "SM21", MNo: "R49", Text: "Communication error", Severity: sapcontrol.STATECOLOR_RED}
+	second := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:05", Client: "100", User: "BATCH", MNo: "AB0", Text: "Run-time error", Severity: sapcontrol.STATECOLOR_YELLOW}
+	third := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:05", Client: "100", User: "BATCH", MNo: "AB1", Text: "Short dump", Severity: sapcontrol.STATECOLOR_YELLOW}
+	gomock.InOrder(
+		mockWebService.EXPECT().ABAPReadSyslog(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPReadSyslogResponse{
+			Log: []*sapcontrol.SyslogEntry{first, second},
+		}, nil),
+		mockWebService.EXPECT().ABAPReadSyslog(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPReadSyslogResponse{
+			Log: []*sapcontrol.SyslogEntry{first, second, third},
+		}, nil),
+	)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	testutil.CollectAndCount(collector)
+	entries := loki.Received()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Communication error", entries[0].Line)
+	assert.Equal(t, map[string]string{
+		"level": "error", "client": "000", "user": "DDIC", "tcode": "SM21", "message_number": "R49",
+		"instance_name": "D01", "instance_number": "1", "SID": "HA1", "instance_hostname": "sapha1pas",
+	}, entries[0].Labels)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), entries[0].Ts)
+
+	expectedMetrics := `
+	# HELP sap_syslog_entries_forwarded ABAP syslog entries forwarded to Loki
+	# TYPE sap_syslog_entries_forwarded counter
+	sap_syslog_entries_forwarded{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1"} 3
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics), "sap_syslog_entries_forwarded")
+	assert.NoError(t, err)
+
+	// the second read only forwards the entry written in the same second as the last forwarded one
+	entries = loki.Received()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "Short dump", entries[0].Line)
+}
+
+func TestSyslogEntriesInReverseOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := newMockWebService(ctrl)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil).AnyTimes()
+
+	first := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:00", MNo: "R49", Text: "Communication error", Severity: sapcontrol.STATECOLOR_RED}
+	second := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:05", MNo: "AB0", Text: "Run-time error", Severity: sapcontrol.STATECOLOR_YELLOW}
+	third := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:10", MNo: "AB1", Text: "Short dump", Severity: sapcontrol.STATECOLOR_YELLOW}
+	fourth := &sapcontrol.SyslogEntry{Time: "2025 03 01 10:00:08", MNo: "AB1", Text: "Another short dump", Severity: sapcontrol.STATECOLOR_YELLOW}
+	gomock.InOrder(
+		mockWebService.EXPECT().ABAPReadSyslog(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPReadSyslogResponse{
+			Log: []*sapcontrol.SyslogEntry{second, first},
+		}, nil),
+		// two new entries, the newest one first
+		mockWebService.EXPECT().ABAPReadSyslog(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPReadSyslogResponse{
+			Log: []*sapcontrol.SyslogEntry{third, fourth, second, first},
+		}, nil),
+	)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	testutil.CollectAndCount(collector)
+	entries := loki.Received()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Run-time error", entries[0].Line)
+	assert.Equal(t, "Communication error", entries[1].Line)
+
+	testutil.CollectAndCount(collector)
+	entries = loki.Received()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Short dump", entries[0].Line)
+	assert.Equal(t, "Another short dump", entries[1].Line)
+}
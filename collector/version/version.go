@@ -0,0 +1,132 @@
+package version
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// VersionInfo looks like "753, patch 1100, changelist 2062427, RKS compatibility level 1, optimized, opt (Oct 11 2023 09:50:09), linuxx86_64"
+var (
+	patchRegexp     = regexp.MustCompile(`patch (\d+)`)
+	buildTimeRegexp = regexp.MustCompile(`\(([^)]*\d{2}:\d{2}:\d{2})\)`)
+)
+
+const (
+	// build time layout of the VersionInfo string
+	kernelBuildTimeLayout = "Jan _2 2006 15:04:05"
+	// layout of the build_time label
+	buildTimeLabelLayout = "2006-01-02 15:04:05"
+)
+
+type versionCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+}
+
+func NewCollector(webService sapcontrol.WebService) (*versionCollector, error) {
+
+	c := &versionCollector{
+		collector.NewDefaultCollector("instance"),
+		webService,
+		config.NewLogger("version"),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	c.SetDescriptor("version_info", "Version of the instance executables, the value is always 1",
+		[]string{"filename", "kernel_release", "patch_level", "build_time", "instance_name", "instance_number", "SID", "instance_hostname"})
+
+	return c, nil
+}
+
+func (c *versionCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting Version metrics")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := c.recordVersionInfo(ctx, ch)
+	if err != nil {
+		log.Errorf("Version Collector: %s", err)
+	}
+}
+
+func (c *versionCollector) recordVersionInfo(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordVersionInfo collecting")
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordVersionInfo")
+	}
+	log.Debugf("recordVersionInfo: Instances in the list: %d", len(instanceInfo))
+
+	for _, instance := range instanceInfo {
+
+		commonLabels := []string{
+			instance.Name,
+			strconv.Itoa(int(instance.InstanceNr)),
+			instance.SID,
+			instance.Hostname,
+		}
+
+		versionInfo, err := c.webService.GetVersionInfo(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordVersionInfo: %v", err)
+			continue
+		}
+
+		for _, version := range versionInfo.Versions {
+			release, patch, kernelBuildTime := parseVersionInfo(version.VersionInfo)
+			buildTime, err := formatBuildTime(kernelBuildTime, version.Time)
+			if err != nil {
+				log.Debugf("recordVersionInfo: %s: %v", version.Filename, err)
+			}
+			labels := append([]string{version.Filename, release, patch, buildTime}, commonLabels...)
+			ch <- c.MakeGaugeMetric("version_info", 1, labels...)
+		}
+	}
+	return nil
+}
+
+// splits the VersionInfo string into kernel release, patch level and build time, missing parts are empty
+func parseVersionInfo(versionInfo string) (string, string, string) {
+	release, _, _ := strings.Cut(versionInfo, ",")
+	release = strings.TrimSpace(release)
+
+	patch := ""
+	if m := patchRegexp.FindStringSubmatch(versionInfo); m != nil {
+		patch = m[1]
+	}
+	buildTime := ""
+	if m := buildTimeRegexp.FindStringSubmatch(versionInfo); m != nil {
+		buildTime = m[1]
+	}
+	return release, patch, buildTime
+}
+
+// returns the build time from the VersionInfo string, or else the file time, in the build_time label layout
+func formatBuildTime(kernelBuildTime string, fileTime string) (string, error) {
+	if t, err := time.Parse(kernelBuildTimeLayout, kernelBuildTime); err == nil {
+		return t.Format(buildTimeLabelLayout), nil
+	}
+	t, err := sapcontrol.ParseSAPTime(fileTime, time.UTC)
+	if err != nil {
+		return "", errors.Wrapf(err, "build time %q", kernelBuildTime)
+	}
+	return t.Format(buildTimeLabelLayout), nil
+}
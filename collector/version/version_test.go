@@ -0,0 +1,84 @@
+package version
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+)
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestParseVersionInfo(t *testing.T) {
+	release, patch, buildTime := parseVersionInfo("753, patch 1100, changelist 2062427, RKS compatibility level 1, optimized, opt (Oct 11 2023 09:50:09), linuxx86_64")
+	assert.Equal(t, "753", release)
+	assert.Equal(t, "1100", patch)
+	assert.Equal(t, "Oct 11 2023 09:50:09", buildTime)
+
+	release, patch, buildTime = parseVersionInfo("7.89")
+	assert.Equal(t, "7.89", release)
+	assert.Equal(t, "", patch)
+	assert.Equal(t, "", buildTime)
+}
+
+func TestFormatBuildTime(t *testing.T) {
+	for _, tc := range []struct {
+		kernelBuildTime string
+		fileTime        string
+		expected        string
+		err             bool
+	}{
+		{"Oct 11 2023 09:50:09", "2023 10 12 08:00:00", "2023-10-11 09:50:09", false},
+		{"Feb  3 2024 17:05:00", "", "2024-02-03 17:05:00", false},
+		{"", "2023 01 17 14:42:03", "2023-01-17 14:42:03", false},
+		{"11.10.2023 09:50:09", "2023 01 17 14:42:03", "2023-01-17 14:42:03", false},
+		{"", "", "", true},
+		{"", "yesterday", "", true},
+	} {
+		buildTime, err := formatBuildTime(tc.kernelBuildTime, tc.fileTime)
+		assert.Equal(t, tc.expected, buildTime)
+		assert.Equal(t, tc.err, err != nil)
+	}
+}
+
+func TestVersionInfoMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil)
+	mockWebService.EXPECT().GetVersionInfo(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.GetVersionInfoResponse{
+		Versions: []*sapcontrol.InstanceVersionInfo{
+			{Filename: "/usr/sap/HA1/D01/exe/disp+work", VersionInfo: "753, patch 1100, changelist 2062427, optimized, opt (Oct 11 2023 09:50:09), linuxx86_64", Time: "2023 10 11 09:50:09"},
+			{Filename: "/usr/sap/HA1/D01/exe/sapstartsrv", VersionInfo: "753, patch 1000, changelist 2030450", Time: "2023 01 17 14:42:03"},
+		},
+	}, nil)
+
+	expectedMetrics := `
+	# HELP sap_instance_version_info Version of the instance executables, the value is always 1
+	# TYPE sap_instance_version_info gauge
+	sap_instance_version_info{SID="HA1",build_time="2023-01-17 14:42:03",filename="/usr/sap/HA1/D01/exe/sapstartsrv",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",kernel_release="753",patch_level="1000"} 1
+	sap_instance_version_info{SID="HA1",build_time="2023-10-11 09:50:09",filename="/usr/sap/HA1/D01/exe/disp+work",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",kernel_release="753",patch_level="1100"} 1
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics))
+	assert.NoError(t, err)
+}
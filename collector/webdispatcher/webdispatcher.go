@@ -0,0 +1,129 @@
+package webdispatcher
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vgrusdev/sap_system_exporter/collector"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+)
+
+// instance feature of the SAP Web Dispatcher, as reported by GetSystemInstanceList
+const webDispatcherFeature = "WEBDISP"
+
+type webDispatcherCollector struct {
+	collector.DefaultCollector
+	webService sapcontrol.WebService
+	logger     *config.Logger
+}
+
+func NewCollector(webService sapcontrol.WebService) (*webDispatcherCollector, error) {
+
+	c := &webDispatcherCollector{
+		collector.NewDefaultCollector("webdispatcher"),
+		webService,
+		config.NewLogger("webdispatcher"),
+	}
+	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+
+	backendLabels := []string{"backend_sid", "backend_instance", "backend_hostname", "backend_protocol", "backend_type",
+		"instance_name", "instance_number", "SID", "instance_hostname"}
+
+	c.SetDescriptor("backend_status", "Web Dispatcher backend server status, the status text is in the status label",
+		append([]string{"status"}, backendLabels...))
+	c.SetDescriptor("backend_capacity", "Web Dispatcher backend server capacity", backendLabels)
+	c.SetDescriptor("backend_load", "Web Dispatcher backend server load", backendLabels)
+	c.SetDescriptor("backend_connections_now", "Current number of connections to the backend server", append([]string{"security"}, backendLabels...))
+	c.SetDescriptor("backend_connections_high", "Peak number of connections to the backend server", append([]string{"security"}, backendLabels...))
+	c.SetDescriptor("backend_connections_max", "Maximum number of connections to the backend server", append([]string{"security"}, backendLabels...))
+	c.SetDescriptor("backend_requests", "Requests forwarded to the backend server", append([]string{"request_type"}, backendLabels...))
+	c.SetDescriptor("backend_response_time_min_seconds", "Minimum response time of the backend server", backendLabels)
+	c.SetDescriptor("backend_response_time_avg_seconds", "Average response time of the backend server", backendLabels)
+	c.SetDescriptor("backend_response_time_last_seconds", "Last response time of the backend server", backendLabels)
+	c.SetDescriptor("backend_ping_time_last_seconds", "Last ping time of the backend server", backendLabels)
+
+	return c, nil
+}
+
+func (c *webDispatcherCollector) Collect(ch chan<- prometheus.Metric) {
+	log := c.logger
+	log.Debug("Collecting Web Dispatcher metrics")
+
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	timeout := v.GetDuration("scrape_timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := c.recordServerList(ctx, ch)
+	if err != nil {
+		log.Errorf("Web Dispatcher Collector: %s", err)
+	}
+}
+
+func (c *webDispatcherCollector) recordServerList(ctx context.Context, ch chan<- prometheus.Metric) error {
+	log := c.logger
+	log.Debug("recordServerList collecting")
+
+	instanceInfo, err := c.webService.GetCachedInstanceList(ctx)
+	if err != nil {
+		return errors.Wrap(err, "recordServerList")
+	}
+	log.Debugf("recordServerList: Instances in the list: %d", len(instanceInfo))
+
+	for _, instance := range instanceInfo {
+
+		if !strings.Contains(strings.ToUpper(instance.Features), webDispatcherFeature) {
+			continue
+		}
+
+		serverList, err := c.webService.WebDispGetServerList(ctx, instance.Endpoint)
+		if err != nil {
+			log.Errorf("recordServerList: %v", err)
+			continue
+		}
+
+		for _, server := range serverList.Servers {
+			labels := []string{
+				server.Sid,
+				server.Instance,
+				server.Hostname,
+				server.Protocol,
+				server.Type,
+				instance.Name,
+				strconv.Itoa(int(instance.InstanceNr)),
+				instance.SID,
+				instance.Hostname,
+			}
+			plain := append([]string{"plain"}, labels...)
+			secure := append([]string{"secure"}, labels...)
+
+			ch <- c.MakeGaugeMetric("backend_status", 1, append([]string{server.Status}, labels...)...)
+			ch <- c.MakeGaugeMetric("backend_capacity", float64(server.Capacity), labels...)
+			ch <- c.MakeGaugeMetric("backend_load", float64(server.Load), labels...)
+
+			ch <- c.MakeGaugeMetric("backend_connections_now", float64(server.Curconn), plain...)
+			ch <- c.MakeCounterMetric("backend_connections_high", float64(server.Peakconn), plain...)
+			ch <- c.MakeGaugeMetric("backend_connections_max", float64(server.Maxconn), plain...)
+			ch <- c.MakeGaugeMetric("backend_connections_now", float64(server.Seccurconn), secure...)
+			ch <- c.MakeCounterMetric("backend_connections_high", float64(server.Secpeakconn), secure...)
+			ch <- c.MakeGaugeMetric("backend_connections_max", float64(server.Secmaxconn), secure...)
+
+			ch <- c.MakeCounterMetric("backend_requests", float64(server.Reqcntstateless), append([]string{"stateless"}, labels...)...)
+			ch <- c.MakeCounterMetric("backend_requests", float64(server.Reqcntstateful), append([]string{"stateful"}, labels...)...)
+			ch <- c.MakeCounterMetric("backend_requests", float64(server.Reqcntgroup), append([]string{"group"}, labels...)...)
+
+			// the Web Dispatcher reports response and ping times in milliseconds
+			ch <- c.MakeGaugeMetric("backend_response_time_min_seconds", float64(server.Resptimemin)/1000, labels...)
+			ch <- c.MakeGaugeMetric("backend_response_time_avg_seconds", float64(server.Resptimeavg)/1000, labels...)
+			ch <- c.MakeGaugeMetric("backend_response_time_last_seconds", float64(server.Resptimelast)/1000, labels...)
+			ch <- c.MakeGaugeMetric("backend_ping_time_last_seconds", float64(server.Pingtimelast)/1000, labels...)
+		}
+	}
+	return nil
+}
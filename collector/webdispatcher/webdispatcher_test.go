@@ -0,0 +1,82 @@
+package webdispatcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+)
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestBackendMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := fixtures.NewMockWebService(ctrl, nil)
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapwd1", InstanceNr: 90, Features: "WEBDISP"}, Name: "W90", SID: "WD1", Endpoint: "http://sapwd1:59013"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+	}, nil)
+	mockWebService.EXPECT().WebDispGetServerList(gomock.Any(), "http://sapwd1:59013").Return(&sapcontrol.WebDispGetServerListResponse{
+		Servers: []*sapcontrol.WebDispServer{
+			{
+				Sid: "HA1", Instance: "sapha1pas_HA1_01", Hostname: "sapha1pas", Protocol: "HTTP", Type: "ABAP", Status: "ACTIVE",
+				Capacity: 20, Load: 3, Curconn: 4, Peakconn: 10, Maxconn: 500, Seccurconn: 1, Secpeakconn: 2, Secmaxconn: 500,
+				Reqcntstateless: 1000, Reqcntstateful: 20, Reqcntgroup: 5,
+				Resptimemin: 2, Resptimeavg: 150, Resptimelast: 40, Pingtimelast: 1,
+			},
+			// the same backend serving another type of application
+			{
+				Sid: "HA1", Instance: "sapha1pas_HA1_01", Hostname: "sapha1pas", Protocol: "HTTP", Type: "J2EE", Status: "ACTIVE",
+				Capacity: 20, Curconn: 2, Reqcntstateless: 10, Resptimeavg: 300,
+			},
+		},
+	}, nil)
+
+	expectedMetrics := `
+	# HELP sap_webdispatcher_backend_connections_now Current number of connections to the backend server
+	# TYPE sap_webdispatcher_backend_connections_now gauge
+	sap_webdispatcher_backend_connections_now{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90",security="plain"} 4
+	sap_webdispatcher_backend_connections_now{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90",security="plain"} 2
+	sap_webdispatcher_backend_connections_now{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90",security="secure"} 1
+	sap_webdispatcher_backend_connections_now{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90",security="secure"} 0
+	# HELP sap_webdispatcher_backend_requests Requests forwarded to the backend server
+	# TYPE sap_webdispatcher_backend_requests counter
+	sap_webdispatcher_backend_requests{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90",request_type="group"} 5
+	sap_webdispatcher_backend_requests{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90",request_type="group"} 0
+	sap_webdispatcher_backend_requests{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90",request_type="stateful"} 20
+	sap_webdispatcher_backend_requests{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90",request_type="stateful"} 0
+	sap_webdispatcher_backend_requests{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90",request_type="stateless"} 1000
+	sap_webdispatcher_backend_requests{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90",request_type="stateless"} 10
+	# HELP sap_webdispatcher_backend_response_time_avg_seconds Average response time of the backend server
+	# TYPE sap_webdispatcher_backend_response_time_avg_seconds gauge
+	sap_webdispatcher_backend_response_time_avg_seconds{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90"} 0.15
+	sap_webdispatcher_backend_response_time_avg_seconds{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90"} 0.3
+	# HELP sap_webdispatcher_backend_status Web Dispatcher backend server status, the status text is in the status label
+	# TYPE sap_webdispatcher_backend_status gauge
+	sap_webdispatcher_backend_status{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="ABAP",instance_hostname="sapwd1",instance_name="W90",instance_number="90",status="ACTIVE"} 1
+	sap_webdispatcher_backend_status{SID="WD1",backend_hostname="sapha1pas",backend_instance="sapha1pas_HA1_01",backend_protocol="HTTP",backend_sid="HA1",backend_type="J2EE",instance_hostname="sapwd1",instance_name="W90",instance_number="90",status="ACTIVE"} 1
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_webdispatcher_backend_connections_now", "sap_webdispatcher_backend_requests",
+		"sap_webdispatcher_backend_response_time_avg_seconds", "sap_webdispatcher_backend_status")
+	assert.NoError(t, err)
+}
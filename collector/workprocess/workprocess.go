@@ -3,8 +3,11 @@ package workprocess
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	//"strings"
 
@@ -13,15 +16,56 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/vgrusdev/promtail-client/promtail"
 	"github.com/vgrusdev/sap_system_exporter/collector"
 	"github.com/vgrusdev/sap_system_exporter/internal/config"
 	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
 )
 
+// workprocess_label_policy values, the labels of the single work process series
+const (
+	// pid, client, user and the other work process table values, as is
+	labelPolicyFull = "full"
+	// no single work process series, the work processes sharing type, status, reason and client are counted in the grouped series
+	labelPolicyAggregated = "aggregated"
+	// only the work process type and number, which do not change while the instance runs
+	labelPolicyStable = "stable"
+)
+
 type workprocessCollector struct {
 	collector.DefaultCollector
 	webService sapcontrol.WebService
 	logger     *config.Logger
+	// workprocess_label_policy
+	labelPolicy string
+	// workprocess_long_running_thresholds, ascending
+	thresholds []time.Duration
+	mu         sync.Mutex
+	// dialog steps running longer than the lowest threshold at the last scrape
+	longRunningSteps map[dialogStep]bool
+}
+
+// identifies a dialog step run by a work process, a new step of the same work process is a different long-runner
+type dialogStep struct {
+	endpoint string
+	no       string
+	pid      string
+	program  string
+	client   string
+	user     string
+}
+
+// the labels of the work processes summed up by the aggregated label policy
+type wpGroup struct {
+	wpType string
+	status string
+	reason string
+	client string
+}
+
+type wpGroupStats struct {
+	count int
+	cpu   float64
 }
 
 func NewCollector(webService sapcontrol.WebService) (*workprocessCollector, error) {
@@ -30,18 +74,51 @@ func NewCollector(webService sapcontrol.WebService) (*workprocessCollector, erro
 		collector.NewDefaultCollector("workprocess"),
 		webService,
 		config.NewLogger("workprocess"),
+		"",
+		[]time.Duration{},
+		sync.Mutex{},
+		make(map[dialogStep]bool),
 	}
-	c.logger.SetLevel(webService.GetMyClient().GetMyConfig().Viper.GetString("log_level"))
+	v := webService.GetMyClient().GetMyConfig().Viper
+	c.logger.SetLevel(v.GetString("log_level"))
+
+	for _, t := range v.GetStringSlice("workprocess_long_running_thresholds") {
+		threshold, err := time.ParseDuration(t)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid workprocess_long_running_thresholds value")
+		}
+		c.thresholds = append(c.thresholds, threshold)
+	}
+	sort.Slice(c.thresholds, func(i, j int) bool { return c.thresholds[i] < c.thresholds[j] })
 
 	c.SetDescriptor("dispatcher_work_processes", "Dispatcher work process counts by type and status",
 		[]string{"wp_type", "status", "instance_name", "instance_number", "SID", "instance_hostname"})
 
-	c.SetDescriptor("dispatcher_work_processes_status", "Status of SAP process",
-		[]string{"wp_type", "status", "pid", "name", "description", "client", "user", "instance_name", "instance_number", "SID", "instance_hostname"})
-	c.SetDescriptor("dispatcher_work_processes_cpu", "SAP process CPU usage counter in seconds",
-		[]string{"wp_type", "status", "pid", "name", "description", "client", "user", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.labelPolicy = v.GetString("workprocess_label_policy")
+	switch c.labelPolicy {
+	case labelPolicyFull:
+		wpLabels := []string{"wp_type", "status", "pid", "name", "description", "client", "user", "instance_name", "instance_number", "SID", "instance_hostname"}
+		c.SetDescriptor("dispatcher_work_processes_status", "Status of SAP process", wpLabels)
+		c.SetDescriptor("dispatcher_work_processes_cpu", "SAP process CPU usage counter in seconds", wpLabels)
+	case labelPolicyAggregated:
+		wpLabels := []string{"wp_type", "status", "description", "client", "instance_name", "instance_number", "SID", "instance_hostname"}
+		c.SetDescriptor("dispatcher_work_processes_grouped", "Work process counts by type, status, reason and client", wpLabels)
+		c.SetDescriptor("dispatcher_work_processes_grouped_cpu_seconds", "CPU usage in seconds of the work processes by type, status, reason and client", wpLabels)
+	case labelPolicyStable:
+		wpLabels := []string{"wp_type", "name", "instance_name", "instance_number", "SID", "instance_hostname"}
+		c.SetDescriptor("dispatcher_work_processes_status", "Status of SAP process", wpLabels)
+		c.SetDescriptor("dispatcher_work_processes_cpu", "SAP process CPU usage counter in seconds", wpLabels)
+	default:
+		return nil, errors.Errorf("invalid workprocess_label_policy value: %q", c.labelPolicy)
+	}
+	c.SetDescriptor("dispatcher_work_processes_cpu_by_status", "CPU usage in seconds of the work processes by type and status",
+		[]string{"wp_type", "status", "instance_name", "instance_number", "SID", "instance_hostname"})
 	//c.SetDescriptor("dispatcher_work_processes_elapsed", "Elapsed time of SAP process in seconds",
 	//	[]string{"wp_type", "status", "pid", "name", "description", "client", "user", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("dispatcher_work_processes_long_running", "Work processes running their current request longer than the threshold",
+		[]string{"wp_type", "threshold_seconds", "instance_name", "instance_number", "SID", "instance_hostname"})
+	c.SetDescriptor("dispatcher_work_processes_max_runtime_seconds", "Longest runtime of the current request of the work processes",
+		[]string{"wp_type", "instance_name", "instance_number", "SID", "instance_hostname"})
 
 	return c, nil
 }
@@ -73,11 +150,34 @@ func (c *workprocessCollector) recordWorkProcessStats(ctx context.Context, ch ch
 	}
 	log.Debugf("recordWorkProcessStats: Instances in the list: %d", len(instanceInfo))
 
+	abapInstances := []sapcontrol.InstanceInfo{}
 	for _, instance := range instanceInfo {
+		if strings.Contains(strings.ToUpper(instance.Features), "ABAP") {
+			abapInstances = append(abapInstances, instance)
+		}
+	}
 
-		if !strings.Contains(strings.ToUpper(instance.Features), "ABAP") {
-			continue
+	v := c.webService.GetMyClient().GetMyConfig().Viper
+	if v.GetBool("workprocess_system_wp_table") {
+		wpTables, err := c.getSystemWPTables(ctx, abapInstances)
+		if err == nil {
+			// the instances without a row, e.g. running on a virtual host name, are read one by one
+			missing := []sapcontrol.InstanceInfo{}
+			for _, instance := range abapInstances {
+				if wpTable, ok := wpTables[instance.Endpoint]; ok {
+					c.sendInstanceMetrics(ch, instance, wpTable)
+				} else {
+					missing = append(missing, instance)
+				}
+			}
+			abapInstances = missing
+		} else {
+			// e.g. the user is not authorized for the system-wide call, fall back to the per-instance calls
+			log.Warnf("recordWorkProcessStats: %v, falling back to ABAPGetWPTable", err)
 		}
+	}
+
+	for _, instance := range abapInstances {
 		url := instance.Endpoint
 
 		wpTable, err := c.webService.ABAPGetWPTable(ctx, url)
@@ -85,40 +185,127 @@ func (c *workprocessCollector) recordWorkProcessStats(ctx context.Context, ch ch
 			log.Errorf("recordWorkProcessStats: %v", err)
 			continue
 		}
+		c.sendInstanceMetrics(ch, instance, wpTable.WorkProcess)
+	}
+	log.Debug("recordWorkProcessStats success")
+	return nil
+}
+
+// reads the work processes of the whole system from the central instance, by instance endpoint
+func (c *workprocessCollector) getSystemWPTables(ctx context.Context, instances []sapcontrol.InstanceInfo) (map[string][]*sapcontrol.WorkProcess, error) {
+	log := c.logger
 
-		commonLabels := []string{
-			instance.Name,
-			strconv.Itoa(int(instance.InstanceNr)),
-			instance.SID,
-			instance.Hostname,
+	sapURL := c.webService.GetMyClient().GetMyConfig().Viper.GetString("sap_control_url")
+	systemWPTable, err := c.webService.ABAPGetSystemWPTable(ctx, sapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Instance field looks like "sapha1pas_HA1_01"
+	endpoints := make(map[string]string)
+	for _, instance := range instances {
+		name := fmt.Sprintf("%s_%s_%02d", instance.Hostname, instance.SID, instance.InstanceNr)
+		endpoints[strings.ToUpper(name)] = instance.Endpoint
+	}
+
+	wpTables := make(map[string][]*sapcontrol.WorkProcess)
+	for _, wp := range systemWPTable.WorkProcess {
+		endpoint, ok := endpoints[strings.ToUpper(wp.Instance)]
+		if !ok {
+			log.Warnf("getSystemWPTables: instance %s is not in the instance list", wp.Instance)
+			continue
 		}
+		wpTables[endpoint] = append(wpTables[endpoint], &wp.WorkProcess)
+	}
+	return wpTables, nil
+}
 
-		// Process work processes
-		wpCounts := make(map[string]map[string]int)
-		for _, wp := range wpTable.WorkProcess {
-			wpType := wp.Type
-			status := wp.Status
+func (c *workprocessCollector) sendInstanceMetrics(ch chan<- prometheus.Metric, instance sapcontrol.InstanceInfo, workProcesses []*sapcontrol.WorkProcess) {
 
-			if wpCounts[wpType] == nil {
-				wpCounts[wpType] = make(map[string]int)
-			}
-			wpCounts[wpType][status]++
+	commonLabels := []string{
+		instance.Name,
+		strconv.Itoa(int(instance.InstanceNr)),
+		instance.SID,
+		instance.Hostname,
+	}
 
-			// Send detailed process metrics
-			c.sendWorkProcessMetrics(ch, commonLabels, wp)
+	// Process work processes
+	wpCounts := make(map[string]map[string]int)
+	wpCPU := make(map[string]map[string]float64)
+	maxRuntime := make(map[string]float64)
+	longRunning := make(map[string][]int)
+	longRunningSteps := make(map[dialogStep]*sapcontrol.WorkProcess)
+	aggregated := make(map[wpGroup]*wpGroupStats)
+	for _, wp := range workProcesses {
+		wpType := wp.Type
+		status := wp.Status
 
+		if wpCounts[wpType] == nil {
+			wpCounts[wpType] = make(map[string]int)
+			wpCPU[wpType] = make(map[string]float64)
+			longRunning[wpType] = make([]int, len(c.thresholds))
+		}
+		wpCounts[wpType][status]++
+		if cpu, err := sapcontrol.ParceCPUTime(wp.Cpu); err == nil {
+			wpCPU[wpType][status] += cpu
+		}
+
+		// Send detailed process metrics
+		if c.labelPolicy == labelPolicyAggregated {
+			group := wpGroup{wp.Type, wp.Status, wp.Reason, wp.Client}
+			if aggregated[group] == nil {
+				aggregated[group] = &wpGroupStats{}
+			}
+			aggregated[group].count++
+			if cpu, err := sapcontrol.ParceCPUTime(wp.Cpu); err == nil {
+				aggregated[group].cpu += cpu
+			}
+		} else {
+			c.sendWorkProcessMetrics(ch, commonLabels, wp)
 		}
 
-		// Send aggregated work process metrics
-		for wpType, statusCounts := range wpCounts {
-			for status, count := range statusCounts {
-				labels := append([]string{wpType, status}, commonLabels...)
-				ch <- c.MakeGaugeMetric("dispatcher_work_processes", float64(count), labels...)
+		runtime, ok := parseRuntime(wp.Time)
+		if !ok {
+			continue
+		}
+		if runtime.Seconds() > maxRuntime[wpType] {
+			maxRuntime[wpType] = runtime.Seconds()
+		}
+		for i, threshold := range c.thresholds {
+			if runtime > threshold {
+				longRunning[wpType][i]++
 			}
 		}
+		if wpType == "DIA" && len(c.thresholds) > 0 && runtime > c.thresholds[0] {
+			longRunningSteps[dialogStep{instance.Endpoint, wp.No, wp.Pid, wp.Program, wp.Client, wp.User}] = wp
+		}
+	}
+
+	// every type gets a series, so that it drops to 0 when the long-runners finish
+	for wpType, counts := range longRunning {
+		for i, count := range counts {
+			labels := append([]string{wpType, strconv.FormatFloat(c.thresholds[i].Seconds(), 'f', -1, 64)}, commonLabels...)
+			ch <- c.MakeGaugeMetric("dispatcher_work_processes_long_running", float64(count), labels...)
+		}
+		ch <- c.MakeGaugeMetric("dispatcher_work_processes_max_runtime_seconds", maxRuntime[wpType], append([]string{wpType}, commonLabels...)...)
+	}
+	c.pushNewLongRunners(instance, commonLabels, longRunningSteps)
+
+	// the groups change members, so the CPU usage is a gauge
+	for group, stats := range aggregated {
+		labels := append([]string{group.wpType, group.status, group.reason, group.client}, commonLabels...)
+		ch <- c.MakeGaugeMetric("dispatcher_work_processes_grouped", float64(stats.count), labels...)
+		ch <- c.MakeGaugeMetric("dispatcher_work_processes_grouped_cpu_seconds", stats.cpu, labels...)
+	}
+
+	// Send aggregated work process metrics
+	for wpType, statusCounts := range wpCounts {
+		for status, count := range statusCounts {
+			labels := append([]string{wpType, status}, commonLabels...)
+			ch <- c.MakeGaugeMetric("dispatcher_work_processes", float64(count), labels...)
+			ch <- c.MakeGaugeMetric("dispatcher_work_processes_cpu_by_status", wpCPU[wpType][status], labels...)
+		}
 	}
-	log.Debug("recordWorkProcessStats success")
-	return nil
 }
 
 func (c *workprocessCollector) sendWorkProcessMetrics(ch chan<- prometheus.Metric, commonLabels []string, wp *sapcontrol.WorkProcess) {
@@ -135,7 +322,13 @@ func (c *workprocessCollector) sendWorkProcessMetrics(ch chan<- prometheus.Metri
 		statusValue = 0
 	}
 
-	labels := append([]string{wp.Type, wp.Status, wp.Pid, fmt.Sprintf("WP-%s", wp.No), wp.Reason, wp.Client, wp.User}, commonLabels...)
+	var labels []string
+	switch c.labelPolicy {
+	case labelPolicyStable:
+		labels = append([]string{wp.Type, fmt.Sprintf("WP-%s", wp.No)}, commonLabels...)
+	default:
+		labels = append([]string{wp.Type, wp.Status, wp.Pid, fmt.Sprintf("WP-%s", wp.No), wp.Reason, wp.Client, wp.User}, commonLabels...)
+	}
 
 	ch <- c.MakeGaugeMetric("dispatcher_work_processes_status", float64(statusValue), labels...)
 
@@ -150,3 +343,54 @@ func (c *workprocessCollector) sendWorkProcessMetrics(ch chan<- prometheus.Metri
 	//	ch <- c.MakeGaugeMetric("dispatcher_work_processes_elapsed", elapsed, labels...)
 	//}
 }
+
+// the Time field of the work process table holds the runtime of the current request in seconds, it is empty for idle work processes
+func parseRuntime(t string) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(strings.TrimSpace(t))
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// remembers the long-running dialog steps of the instance, and pushes the new ones to Loki
+func (c *workprocessCollector) pushNewLongRunners(instance sapcontrol.InstanceInfo, commonLabels []string, steps map[dialogStep]*sapcontrol.WorkProcess) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for step := range c.longRunningSteps {
+		if _, ok := steps[step]; !ok && step.endpoint == instance.Endpoint {
+			delete(c.longRunningSteps, step)
+		}
+	}
+
+	var loki_client promtail.Client
+	if c.webService.GetMyClient().GetMyConfig().Viper.GetBool("workprocess_long_running_loki") {
+		loki_client = c.webService.GetLokiClient()
+	}
+	for step, wp := range steps {
+		if c.longRunningSteps[step] {
+			continue
+		}
+		c.longRunningSteps[step] = true
+		if loki_client == nil {
+			continue
+		}
+		loki_client.Single() <- &promtail.SingleEntry{
+			Labels: map[string]string{
+				"level":             "warning",
+				"wp_number":         wp.No,
+				"program":           wp.Program,
+				"table":             wp.Table,
+				"client":            wp.Client,
+				"user":              wp.User,
+				"instance_name":     commonLabels[0],
+				"instance_number":   commonLabels[1],
+				"SID":               commonLabels[2],
+				"instance_hostname": commonLabels[3],
+			},
+			Ts:   time.Now(),
+			Line: fmt.Sprintf("Dialog work process WP-%s running for %ss: program %s, action %s, table %s", wp.No, strings.TrimSpace(wp.Time), wp.Program, wp.Action, wp.Table),
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package workprocess
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/fixtures"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+func newMockWebService(ctrl *gomock.Controller, systemWPTable bool) *mock_sapcontrol.MockWebService {
+	return fixtures.NewMockWebService(ctrl, map[string]interface{}{
+		"sap_control_url":             "http://sapha1as:50013",
+		"workprocess_system_wp_table": systemWPTable,
+		"workprocess_label_policy":    "full",
+	})
+}
+
+// two dialog instances and the ASCS instance without work processes
+func expectABAPInstances(mockWebService *mock_sapcontrol.MockWebService) {
+	mockWebService.EXPECT().GetCachedInstanceList(gomock.Any()).Return([]sapcontrol.InstanceInfo{
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1as", InstanceNr: 0, Features: "MESSAGESERVER|ENQUE"}, Name: "ASCS00", SID: "HA1", Endpoint: "http://sapha1as:50013"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1pas", InstanceNr: 1, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D01", SID: "HA1", Endpoint: "http://sapha1pas:50113"},
+		{SAPInstance: sapcontrol.SAPInstance{Hostname: "sapha1aas", InstanceNr: 2, Features: "ABAP|GATEWAY|ICMAN|IGS"}, Name: "D02", SID: "HA1", Endpoint: "http://sapha1aas:50213"},
+	}, nil).AnyTimes()
+}
+
+const expectedWorkProcesses = `
+	# HELP sap_workprocess_dispatcher_work_processes Dispatcher work process counts by type and status
+	# TYPE sap_workprocess_dispatcher_work_processes gauge
+	sap_workprocess_dispatcher_work_processes{SID="HA1",instance_hostname="sapha1aas",instance_name="D02",instance_number="2",status="Wait",wp_type="DIA"} 1
+	sap_workprocess_dispatcher_work_processes{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Run",wp_type="DIA"} 1
+	sap_workprocess_dispatcher_work_processes{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Wait",wp_type="BTC"} 1
+`
+
+func TestNewCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl, false)
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Nil(t, err)
+}
+
+func TestNewCollectorInvalidLabelPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebService := newMockWebService(ctrl, false)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("workprocess_label_policy", "none")
+
+	_, err := NewCollector(mockWebService)
+
+	assert.Error(t, err)
+}
+
+func TestWorkProcessesPerInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl, false)
+	expectABAPInstances(mockWebService)
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "1234", Status: "Run", Cpu: "0:05"},
+			{No: "1", Type: "BTC", Pid: "1235", Status: "Wait"},
+		},
+	}, nil)
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1aas:50213").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "2234", Status: "Wait"},
+		},
+	}, nil)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedWorkProcesses), "sap_workprocess_dispatcher_work_processes")
+	assert.NoError(t, err)
+}
+
+func TestWorkProcessesSystemWide(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl, true)
+	expectABAPInstances(mockWebService)
+	mockWebService.EXPECT().ABAPGetSystemWPTable(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.ABAPGetSystemWPTableResponse{
+		WorkProcess: []*sapcontrol.SystemWorkProcess{
+			{Instance: "sapha1pas_HA1_01", WorkProcess: sapcontrol.WorkProcess{No: "0", Type: "DIA", Pid: "1234", Status: "Run", Cpu: "0:05"}},
+			{Instance: "sapha1pas_HA1_01", WorkProcess: sapcontrol.WorkProcess{No: "1", Type: "BTC", Pid: "1235", Status: "Wait"}},
+			{Instance: "sapha1aas_HA1_02", WorkProcess: sapcontrol.WorkProcess{No: "0", Type: "DIA", Pid: "2234", Status: "Wait"}},
+			{Instance: "sapunknown_HA1_03", WorkProcess: sapcontrol.WorkProcess{No: "0", Type: "DIA", Pid: "3234", Status: "Wait"}},
+		},
+	}, nil)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedWorkProcesses), "sap_workprocess_dispatcher_work_processes")
+	assert.NoError(t, err)
+}
+
+func TestWorkProcessesSystemWideUnmatchedInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl, true)
+	expectABAPInstances(mockWebService)
+	// the second instance runs on a virtual host name, its rows do not match the instance list
+	mockWebService.EXPECT().ABAPGetSystemWPTable(gomock.Any(), "http://sapha1as:50013").Return(&sapcontrol.ABAPGetSystemWPTableResponse{
+		WorkProcess: []*sapcontrol.SystemWorkProcess{
+			{Instance: "sapha1pas_HA1_01", WorkProcess: sapcontrol.WorkProcess{No: "0", Type: "DIA", Pid: "1234", Status: "Run", Cpu: "0:05"}},
+			{Instance: "sapha1pas_HA1_01", WorkProcess: sapcontrol.WorkProcess{No: "1", Type: "BTC", Pid: "1235", Status: "Wait"}},
+			{Instance: "vhha1aas_HA1_02", WorkProcess: sapcontrol.WorkProcess{No: "0", Type: "DIA", Pid: "2234", Status: "Wait"}},
+		},
+	}, nil)
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1aas:50213").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "2234", Status: "Wait"},
+		},
+	}, nil)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedWorkProcesses), "sap_workprocess_dispatcher_work_processes")
+	assert.NoError(t, err)
+}
+
+func TestWorkProcessesSystemWideFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebService := newMockWebService(ctrl, true)
+	expectABAPInstances(mockWebService)
+	mockWebService.EXPECT().ABAPGetSystemWPTable(gomock.Any(), gomock.Any()).Return(nil, errors.New("Permission denied"))
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "1234", Status: "Run", Cpu: "0:05"},
+			{No: "1", Type: "BTC", Pid: "1235", Status: "Wait"},
+		},
+	}, nil)
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1aas:50213").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "2234", Status: "Wait"},
+		},
+	}, nil)
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedWorkProcesses), "sap_workprocess_dispatcher_work_processes")
+	assert.NoError(t, err)
+}
+
+func TestLongRunningWorkProcesses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loki := fixtures.NewFakeLokiClient(10)
+	mockWebService := newMockWebService(ctrl, false)
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("workprocess_long_running_thresholds", []string{"600s", "60s"})
+	mockWebService.GetMyClient().GetMyConfig().Viper.Set("workprocess_long_running_loki", true)
+	mockWebService.EXPECT().GetLokiClient().Return(loki).AnyTimes()
+	expectABAPInstances(mockWebService)
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1pas:50113").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "1234", Status: "Run", Time: "742", Program: "ZREPORT", Action: "Sequential Read", Table: "BSEG", Client: "100", User: "JDOE"},
+			{No: "1", Type: "DIA", Pid: "1235", Status: "Run", Time: "5", Program: "SAPMSSY1", Client: "100", User: "BATCH"},
+			{No: "2", Type: "BTC", Pid: "1236", Status: "Run", Time: "90", Program: "RSPO1041", Client: "000", User: "DDIC"},
+			{No: "3", Type: "BTC", Pid: "1237", Status: "Wait"},
+		},
+	}, nil).Times(2)
+	mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1aas:50213").Return(&sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "2234", Status: "Wait"},
+		},
+	}, nil).Times(2)
+
+	expectedMetrics := `
+	# HELP sap_workprocess_dispatcher_work_processes_long_running Work processes running their current request longer than the threshold
+	# TYPE sap_workprocess_dispatcher_work_processes_long_running gauge
+	sap_workprocess_dispatcher_work_processes_long_running{SID="HA1",instance_hostname="sapha1aas",instance_name="D02",instance_number="2",threshold_seconds="60",wp_type="DIA"} 0
+	sap_workprocess_dispatcher_work_processes_long_running{SID="HA1",instance_hostname="sapha1aas",instance_name="D02",instance_number="2",threshold_seconds="600",wp_type="DIA"} 0
+	sap_workprocess_dispatcher_work_processes_long_running{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",threshold_seconds="60",wp_type="BTC"} 1
+	sap_workprocess_dispatcher_work_processes_long_running{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",threshold_seconds="60",wp_type="DIA"} 1
+	sap_workprocess_dispatcher_work_processes_long_running{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",threshold_seconds="600",wp_type="BTC"} 0
+	sap_workprocess_dispatcher_work_processes_long_running{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",threshold_seconds="600",wp_type="DIA"} 1
+	# HELP sap_workprocess_dispatcher_work_processes_max_runtime_seconds Longest runtime of the current request of the work processes
+	# TYPE sap_workprocess_dispatcher_work_processes_max_runtime_seconds gauge
+	sap_workprocess_dispatcher_work_processes_max_runtime_seconds{SID="HA1",instance_hostname="sapha1aas",instance_name="D02",instance_number="2",wp_type="DIA"} 0
+	sap_workprocess_dispatcher_work_processes_max_runtime_seconds{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",wp_type="BTC"} 90
+	sap_workprocess_dispatcher_work_processes_max_runtime_seconds{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",wp_type="DIA"} 742
+`
+
+	collector, err := NewCollector(mockWebService)
+	assert.NoError(t, err)
+
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics),
+		"sap_workprocess_dispatcher_work_processes_long_running", "sap_workprocess_dispatcher_work_processes_max_runtime_seconds")
+	assert.NoError(t, err)
+
+	// only the dialog work process is pushed
+	entries := loki.Received()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "Dialog work process WP-0 running for 742s: program ZREPORT, action Sequential Read, table BSEG", entries[0].Line)
+	assert.Equal(t, "JDOE", entries[0].Labels["user"])
+
+	// the same dialog step is pushed once
+	testutil.CollectAndCount(collector)
+	assert.Len(t, loki.Received(), 0)
+}
+
+func TestWorkProcessLabelPolicies(t *testing.T) {
+	wpTable := &sapcontrol.ABAPGetWPTableResponse{
+		WorkProcess: []*sapcontrol.WorkProcess{
+			{No: "0", Type: "DIA", Pid: "1234", Status: "Run", Cpu: "0:05", Client: "100", User: "JDOE"},
+			{No: "1", Type: "DIA", Pid: "1235", Status: "Run", Cpu: "0:10", Client: "100", User: "BATCH"},
+			{No: "2", Type: "DIA", Pid: "1236", Status: "Run", Cpu: "0:01", Client: "000", User: "DDIC"},
+		},
+	}
+	cases := map[string]string{
+		"aggregated": `
+	# HELP sap_workprocess_dispatcher_work_processes_grouped Work process counts by type, status, reason and client
+	# TYPE sap_workprocess_dispatcher_work_processes_grouped gauge
+	sap_workprocess_dispatcher_work_processes_grouped{SID="HA1",client="000",description="",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Run",wp_type="DIA"} 1
+	sap_workprocess_dispatcher_work_processes_grouped{SID="HA1",client="100",description="",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Run",wp_type="DIA"} 2
+	# HELP sap_workprocess_dispatcher_work_processes_grouped_cpu_seconds CPU usage in seconds of the work processes by type, status, reason and client
+	# TYPE sap_workprocess_dispatcher_work_processes_grouped_cpu_seconds gauge
+	sap_workprocess_dispatcher_work_processes_grouped_cpu_seconds{SID="HA1",client="000",description="",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Run",wp_type="DIA"} 1
+	sap_workprocess_dispatcher_work_processes_grouped_cpu_seconds{SID="HA1",client="100",description="",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Run",wp_type="DIA"} 15
+`,
+		"stable": `
+	# HELP sap_workprocess_dispatcher_work_processes_cpu SAP process CPU usage counter in seconds
+	# TYPE sap_workprocess_dispatcher_work_processes_cpu counter
+	sap_workprocess_dispatcher_work_processes_cpu{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",name="WP-0",wp_type="DIA"} 5
+	sap_workprocess_dispatcher_work_processes_cpu{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",name="WP-1",wp_type="DIA"} 10
+	sap_workprocess_dispatcher_work_processes_cpu{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",name="WP-2",wp_type="DIA"} 1
+	# HELP sap_workprocess_dispatcher_work_processes_cpu_by_status CPU usage in seconds of the work processes by type and status
+	# TYPE sap_workprocess_dispatcher_work_processes_cpu_by_status gauge
+	sap_workprocess_dispatcher_work_processes_cpu_by_status{SID="HA1",instance_hostname="sapha1pas",instance_name="D01",instance_number="1",status="Run",wp_type="DIA"} 16
+`,
+	}
+
+	for policy, expectedMetrics := range cases {
+		t.Run(policy, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWebService := newMockWebService(ctrl, false)
+			mockWebService.GetMyClient().GetMyConfig().Viper.Set("workprocess_label_policy", policy)
+			expectABAPInstances(mockWebService)
+			mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1pas:50113").Return(wpTable, nil)
+			mockWebService.EXPECT().ABAPGetWPTable(gomock.Any(), "http://sapha1aas:50213").Return(&sapcontrol.ABAPGetWPTableResponse{}, nil)
+
+			collector, err := NewCollector(mockWebService)
+			assert.NoError(t, err)
+
+			// the aggregated policy exports no single work process series
+			metricNames := []string{"sap_workprocess_dispatcher_work_processes_cpu", "sap_workprocess_dispatcher_work_processes_status"}
+			if policy == "stable" {
+				metricNames = []string{"sap_workprocess_dispatcher_work_processes_cpu", "sap_workprocess_dispatcher_work_processes_cpu_by_status"}
+			} else {
+				metricNames = append(metricNames, "sap_workprocess_dispatcher_work_processes_grouped", "sap_workprocess_dispatcher_work_processes_grouped_cpu_seconds")
+			}
+			err = testutil.CollectAndCompare(collector, strings.NewReader(expectedMetrics), metricNames...)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -179,6 +179,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("scrape_timeout", "30s")
 	v.SetDefault("send_alerts_to_prom", false)
 	v.SetDefault("alert_samples_max_age", "2h")
+	v.SetDefault("alert_tree_node_patterns", []string{})
+	v.SetDefault("alert_state_file", "")
+	v.SetDefault("alertmanager_url", "")
+	v.SetDefault("alertmanager_resend_interval", "1m")
+	v.SetDefault("alertmanager_http_timeout", "5s")
 	v.SetDefault("loki_url", "")
 	v.SetDefault("loki_name", "sap_alerts")
 	v.SetDefault("loki_tenantid", "sap_alerts")
@@ -187,9 +192,29 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("loki_http_timeout", "1000ms")
 	v.SetDefault("loki_time_location", "Europe/Moscow")
 	v.SetDefault("collect_enqueueserver", true)
+	v.SetDefault("enqueue_lock_table", false)
+	v.SetDefault("enqueue_lock_table_top_n", 10)
 	v.SetDefault("collect_dispatcher", true)
 	v.SetDefault("collect_workprocess", true)
+	v.SetDefault("workprocess_system_wp_table", false)
+	v.SetDefault("workprocess_label_policy", "full")
+	v.SetDefault("workprocess_long_running_thresholds", []string{"60s", "600s", "3600s"})
+	v.SetDefault("workprocess_long_running_loki", false)
 	v.SetDefault("collect_alerts", true)
+	v.SetDefault("collect_icm", true)
+	v.SetDefault("collect_webdispatcher", true)
+	v.SetDefault("collect_ha", false)
+	v.SetDefault("collect_syslog", false)
+	v.SetDefault("syslog_samples_max_age", "2h")
+	v.SetDefault("collect_version", true)
+	v.SetDefault("collect_j2ee", true)
+	v.SetDefault("j2ee_thread_long_running_threshold", "10m")
+	v.SetDefault("j2ee_thread_long_running_loki", false)
+	v.SetDefault("collect_inventory", true)
+	v.SetDefault("inventory_cache_ttl", "6h")
+	v.SetDefault("collect_rfc", false)
+	v.SetDefault("rfc_check_interval", "1h")
+	v.SetDefault("rfc_check_timeout", "5m")
 }
 
 func bindEnvVars(v *viper.Viper) {
@@ -6,6 +6,7 @@ import (
 	//"net/http"
 	"crypto/tls"
 	"strings"
+	"time"
 
 	"github.com/hooklift/gowsdl/soap"
 	//"github.com/spf13/viper"
@@ -74,3 +75,18 @@ func (c *MyClient) CreateSoapClient(endpoint string) *soap.Client {
 func (c *MyClient) GetMyConfig() *config.MyConfig {
 	return c.config
 }
+
+// Location of the SAP system timestamps, the same as used for the Alerts (loki_time_location)
+func (c *MyClient) GetTimeLocation() *time.Location {
+	loc, err := time.LoadLocation(c.config.Viper.GetString("loki_time_location"))
+	if err != nil {
+		c.logger.Warnf("Option loki_time_location incorrect: %s. Use UTC", err)
+		return time.UTC
+	}
+	return loc
+}
+
+// Cache of the SAPControl responses, shared by the collectors
+func (c *MyClient) GetCacheManager() *cache.CacheManager {
+	return c.cacheMgr
+}
@@ -5,8 +5,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"strconv"
-
 	"strings"
+	"time"
 
 	//"github.com/hooklift/gowsdl/soap"
 	"github.com/pkg/errors"
@@ -24,6 +24,9 @@ type WebService interface {
 	/* Returns enque statistic. */
 	EnqGetStatistic(context.Context, string) (*EnqGetStatisticResponse, error)
 
+	/* Returns the enqueue lock table. */
+	EnqGetLockTable(context.Context, string) (*EnqGetLockTableResponse, error)
+
 	/* Returns a list of queue information of work processes and icm (similar to dpmon). */
 	GetQueueStatistic(context.Context, string) (*GetQueueStatisticResponse, error)
 
@@ -33,13 +36,69 @@ type WebService interface {
 	/* Returns a list of available instance features and information how to get it. */
 	GetInstanceProperties(context.Context, string) (*GetInstancePropertiesResponse, error)
 
+	/* Returns a list of version information of the most important instance files. */
+	GetVersionInfo(context.Context, string) (*GetVersionInfoResponse, error)
+
 	/* Custom method to get the current instance data. This is not something natively exposed by the webservice. */
 	GetCurrentInstance(context.Context, string) (*InstanceProperties, error)
 	GetCachedInstanceList(context.Context) ([]InstanceInfo, error)
 	GetCachedProcessList(context.Context, string) ([]ProcessInfo, error)
 
 	GetAlerts(context.Context, string) (*GetAlertsResponse, error)
+	/* Returns CCMS Alert tree as an array, parent-child node relationship is encoded via the parent index of each node (similar to rz20 transaction). */
+	GetAlertTree(context.Context, string) (*GetAlertTreeResponse, error)
 	ABAPGetWPTable(context.Context, string) (*ABAPGetWPTableResponse, error)
+	/* Returns a list of all or all active ABAP workprocesses in the system (similar to sm66 transaction). */
+	ABAPGetSystemWPTable(context.Context, string) (*ABAPGetSystemWPTableResponse, error)
+	/* Returns a list of installed ABAP components in the system as defined in CVERS database table. */
+	ABAPGetComponentList(context.Context, string) (*ABAPGetComponentListResponse, error)
+	/* Returns a list of single point of failure RFC destination definitions. */
+	ABAPCheckRFCDestinations(context.Context, string) (*ABAPCheckRFCDestinationsResponse, error)
+
+	/* Reads the SAP ABAP Syslog (similar to sm21 transaction). */
+	ABAPReadSyslog(context.Context, string) (*ABAPReadSyslogResponse, error)
+
+	/* Returns a list of ICM worker threads. */
+	ICMGetThreadList(context.Context, string) (*ICMGetThreadListResponse, error)
+
+	/* Returns a list of ICM connections. */
+	ICMGetConnectionList(context.Context, string) (*ICMGetConnectionListResponse, error)
+
+	/* Returns a list of ICM HTTP server cache entries. */
+	ICMGetCacheEntries(context.Context, string) (*ICMGetCacheEntriesResponse, error)
+
+	/* Returns a list of SAP Web Dispatcher backend servers. */
+	WebDispGetServerList(context.Context, string) (*WebDispGetServerListResponse, error)
+
+	/* Checks the high availability configuration of the instance. */
+	HACheckConfig(context.Context, string) (*HACheckConfigResponse, error)
+	/* Checks the failover configuration of the instance in the HA cluster. */
+	HACheckFailoverConfig(context.Context, string) (*HACheckFailoverConfigResponse, error)
+	/* Returns HA failover third party information. */
+	HAGetFailoverConfig(context.Context, string) (*HAGetFailoverConfigResponse, error)
+
+	/* Returns a list of J2EE VM heap information. */
+	J2EEGetVMHeapInfo(context.Context, string) (*J2EEGetVMHeapInfoResponse, error)
+	/* Returns a list of J2EE VM garbage collection history. */
+	J2EEGetVMGCHistory(context.Context, string) (*J2EEGetVMGCHistoryResponse, error)
+	/* Returns a list of J2EE processes controlled by jcontrol. */
+	J2EEGetProcessList(context.Context, string) (*J2EEGetProcessListResponse, error)
+	/* Returns a list of J2EE threads. */
+	J2EEGetThreadList(context.Context, string) (*J2EEGetThreadListResponse, error)
+	/* Returns a list of (HTTP) sessions in the J2EE instance (supersedes J2EEGetSessionList, J2EEGetWebSessionList). */
+	J2EEGetWebSessionList2(context.Context, string) (*J2EEGetWebSessionList2Response, error)
+	/* Returns a list of J2EE EJB sessions. */
+	J2EEGetEJBSessionList(context.Context, string) (*J2EEGetEJBSessionListResponse, error)
+	/* Returns a list of J2EE sessions. */
+	J2EEGetSessionList(context.Context, string) (*J2EEGetSessionListResponse, error)
+	/* Returns a list of J2EE cache statistics. */
+	J2EEGetCacheStatistic(context.Context, string) (*J2EEGetCacheStatisticResponse, error)
+	/* Returns a list of J2EE shared table information. */
+	J2EEGetSharedTableInfo(context.Context, string) (*J2EEGetSharedTableInfoResponse, error)
+	/* Returns a list of J2EE cluster message statistics. */
+	J2EEGetClusterMsgList(context.Context, string) (*J2EEGetClusterMsgListResponse, error)
+	/* Returns a list of J2EE services and applications. */
+	J2EEGetComponentList(context.Context, string) (*J2EEGetComponentListResponse, error)
 
 	GetMyClient() *MyClient
 	SetLokiClient(promtail.Client)
@@ -60,6 +119,26 @@ const (
 	STATECOLOR_CODE_RED    STATECOLOR_CODE = 4
 )
 
+type HAVerificationState string
+
+const (
+	HA_VERIFICATION_STATE_SUCCESS HAVerificationState = "SAPControl-HA-SUCCESS"
+	HA_VERIFICATION_STATE_WARNING HAVerificationState = "SAPControl-HA-WARNING"
+	HA_VERIFICATION_STATE_ERROR   HAVerificationState = "SAPControl-HA-ERROR"
+)
+
+type J2EEPSTATE string
+
+const (
+	J2EE_PSTATE_STOPPED      J2EEPSTATE = "SAPControl-J2EE-STOPPED"
+	J2EE_PSTATE_STARTING     J2EEPSTATE = "SAPControl-J2EE-STARTING"
+	J2EE_PSTATE_CORE_RUNNING J2EEPSTATE = "SAPControl-J2EE-CORE-RUNNING"
+	J2EE_PSTATE_RUNNING      J2EEPSTATE = "SAPControl-J2EE-RUNNING"
+	J2EE_PSTATE_STOPPING     J2EEPSTATE = "SAPControl-J2EE-STOPPING"
+	J2EE_PSTATE_MAINTENANCE  J2EEPSTATE = "SAPControl-J2EE-MAINTENANCE"
+	J2EE_PSTATE_UNKNOWN      J2EEPSTATE = "SAPControl-J2EE-UNKNOWN"
+)
+
 type EnqGetStatistic struct {
 	XMLName xml.Name `xml:"urn:SAPControl EnqGetStatistic"`
 }
@@ -95,6 +174,30 @@ type EnqGetStatisticResponse struct {
 	ReplicationState   STATECOLOR `xml:"replication-state,omitempty" json:"replication-state,omitempty"`
 }
 
+type EnqGetLockTable struct {
+	XMLName xml.Name `xml:"urn:SAPControl EnqGetLockTable"`
+}
+
+type EnqGetLockTableResponse struct {
+	XMLName xml.Name   `xml:"urn:SAPControl EnqGetLockTableResponse"`
+	Locks   []*EnqLock `xml:"lock>item,omitempty" json:"lock>item,omitempty"`
+}
+
+type EnqLock struct {
+	Lockname        string `xml:"lock-name,omitempty" json:"lock-name,omitempty"`
+	Lockarg         string `xml:"lock-arg,omitempty" json:"lock-arg,omitempty"`
+	Lockmode        string `xml:"lock-mode,omitempty" json:"lock-mode,omitempty"`
+	Owner           string `xml:"owner,omitempty" json:"owner,omitempty"`
+	Ownervb         string `xml:"owner-vb,omitempty" json:"owner-vb,omitempty"`
+	Usecountowner   int32  `xml:"use-count-owner,omitempty" json:"use-count-owner,omitempty"`
+	Usecountownervb int32  `xml:"use-count-owner-vb,omitempty" json:"use-count-owner-vb,omitempty"`
+	Client          string `xml:"client,omitempty" json:"client,omitempty"`
+	User            string `xml:"user,omitempty" json:"user,omitempty"`
+	Transaction     string `xml:"transaction,omitempty" json:"transaction,omitempty"`
+	Object          string `xml:"object,omitempty" json:"object,omitempty"`
+	Backup          bool   `xml:"backup,omitempty" json:"backup,omitempty"`
+}
+
 type GetInstanceProperties struct {
 	XMLName xml.Name `xml:"urn:SAPControl GetInstanceProperties"`
 }
@@ -104,6 +207,21 @@ type GetInstancePropertiesResponse struct {
 	Properties []*InstanceProperty `xml:"properties>item,omitempty" json:"properties>item,omitempty"`
 }
 
+type GetVersionInfo struct {
+	XMLName xml.Name `xml:"urn:SAPControl GetVersionInfo"`
+}
+
+type GetVersionInfoResponse struct {
+	XMLName  xml.Name               `xml:"urn:SAPControl GetVersionInfoResponse"`
+	Versions []*InstanceVersionInfo `xml:"version>item,omitempty" json:"version>item,omitempty"`
+}
+
+type InstanceVersionInfo struct {
+	Filename    string `xml:"Filename,omitempty" json:"Filename,omitempty"`
+	VersionInfo string `xml:"VersionInfo,omitempty" json:"VersionInfo,omitempty"`
+	Time        string `xml:"Time,omitempty" json:"Time,omitempty"`
+}
+
 type GetProcessList struct {
 	XMLName xml.Name `xml:"urn:SAPControl GetProcessList"`
 }
@@ -188,6 +306,45 @@ type Alert struct {
 	Aid         string     `xml:"Aid,omitempty" json:"Aid,omitempty"`
 }
 
+type GetAlertTree struct {
+	XMLName xml.Name `xml:"urn:SAPControl GetAlertTree"`
+}
+type GetAlertTreeResponse struct {
+	XMLName xml.Name     `xml:"urn:SAPControl GetAlertTreeResponse"`
+	Tree    []*AlertNode `xml:"tree>item,omitempty" json:"tree>item,omitempty"`
+}
+type AlertNode struct {
+	Name           string     `xml:"name,omitempty" json:"name,omitempty"`
+	Parent         int32      `xml:"parent,omitempty" json:"parent,omitempty"`
+	ActualValue    STATECOLOR `xml:"ActualValue,omitempty" json:"ActualValue,omitempty"`
+	Description    string     `xml:"description,omitempty" json:"description,omitempty"`
+	Time           string     `xml:"Time,omitempty" json:"Time,omitempty"`
+	AnalyseTool    string     `xml:"AnalyseTool,omitempty" json:"AnalyseTool,omitempty"`
+	VisibleLevel   string     `xml:"VisibleLevel,omitempty" json:"VisibleLevel,omitempty"`
+	HighAlertValue STATECOLOR `xml:"HighAlertValue,omitempty" json:"HighAlertValue,omitempty"`
+	AlDescription  string     `xml:"AlDescription,omitempty" json:"AlDescription,omitempty"`
+	AlTime         string     `xml:"AlTime,omitempty" json:"AlTime,omitempty"`
+	Tid            string     `xml:"Tid,omitempty" json:"Tid,omitempty"`
+}
+
+type ABAPReadSyslog struct {
+	XMLName xml.Name `xml:"urn:SAPControl ABAPReadSyslog"`
+}
+type ABAPReadSyslogResponse struct {
+	XMLName xml.Name       `xml:"urn:SAPControl ABAPReadSyslogResponse"`
+	Log     []*SyslogEntry `xml:"log>item,omitempty" json:"log>item,omitempty"`
+}
+type SyslogEntry struct {
+	Time     string     `xml:"Time,omitempty" json:"Time,omitempty"`
+	Typ      string     `xml:"Typ,omitempty" json:"Typ,omitempty"`
+	Client   string     `xml:"Client,omitempty" json:"Client,omitempty"`
+	User     string     `xml:"User,omitempty" json:"User,omitempty"`
+	Tcode    string     `xml:"Tcode,omitempty" json:"Tcode,omitempty"`
+	MNo      string     `xml:"MNo,omitempty" json:"MNo,omitempty"`
+	Text     string     `xml:"Text,omitempty" json:"Text,omitempty"`
+	Severity STATECOLOR `xml:"Severity,omitempty" json:"Severity,omitempty"`
+}
+
 type ABAPGetWPTable struct {
 	XMLName xml.Name `xml:"urn:SAPControl ABAPGetWPTable"`
 }
@@ -213,6 +370,396 @@ type WorkProcess struct {
 	Table   string `xml:"Table,omitempty" json:"Table,omitempty"`
 }
 
+type ABAPGetSystemWPTable struct {
+	XMLName    xml.Name `xml:"urn:SAPControl ABAPGetSystemWPTable"`
+	Activeonly bool     `xml:"activeonly,omitempty" json:"activeonly,omitempty"`
+}
+type ABAPGetSystemWPTableResponse struct {
+	XMLName     xml.Name             `xml:"urn:SAPControl ABAPGetSystemWPTableResponse"`
+	WorkProcess []*SystemWorkProcess `xml:"workprocess>item,omitempty" json:"workprocess>item,omitempty"`
+}
+type SystemWorkProcess struct {
+	Instance string `xml:"Instance,omitempty" json:"Instance,omitempty"`
+	WorkProcess
+}
+
+type ABAPGetComponentList struct {
+	XMLName xml.Name `xml:"urn:SAPControl ABAPGetComponentList"`
+}
+type ABAPGetComponentListResponse struct {
+	XMLName    xml.Name         `xml:"urn:SAPControl ABAPGetComponentListResponse"`
+	Components []*ABAPComponent `xml:"component>item,omitempty" json:"component>item,omitempty"`
+}
+type ABAPComponent struct {
+	Component     string `xml:"component,omitempty" json:"component,omitempty"`
+	Release       string `xml:"release,omitempty" json:"release,omitempty"`
+	Patchlevel    string `xml:"patchlevel,omitempty" json:"patchlevel,omitempty"`
+	Componenttype string `xml:"componenttype,omitempty" json:"componenttype,omitempty"`
+	Description   string `xml:"description,omitempty" json:"description,omitempty"`
+}
+
+type ABAPCheckRFCDestinations struct {
+	XMLName xml.Name `xml:"urn:SAPControl ABAPCheckRFCDestinations"`
+}
+type ABAPCheckRFCDestinationsResponse struct {
+	XMLName     xml.Name `xml:"urn:SAPControl ABAPCheckRFCDestinationsResponse"`
+	Destination []string `xml:"destination>item,omitempty" json:"destination>item,omitempty"`
+}
+
+type ICMGetThreadList struct {
+	XMLName xml.Name `xml:"urn:SAPControl ICMGetThreadList"`
+}
+type ICMGetThreadListResponse struct {
+	XMLName xml.Name     `xml:"urn:SAPControl ICMGetThreadListResponse"`
+	Threads []*ICMThread `xml:"thread>item,omitempty" json:"thread>item,omitempty"`
+}
+type ICMThread struct {
+	Name        string `xml:"name,omitempty" json:"name,omitempty"`
+	Id          string `xml:"id,omitempty" json:"id,omitempty"`
+	Requests    int64  `xml:"requests,omitempty" json:"requests,omitempty"`
+	Status      string `xml:"status,omitempty" json:"status,omitempty"`
+	Requesttype string `xml:"requesttype,omitempty" json:"requesttype,omitempty"`
+}
+
+type ICMGetConnectionList struct {
+	XMLName xml.Name `xml:"urn:SAPControl ICMGetConnectionList"`
+}
+type ICMGetConnectionListResponse struct {
+	XMLName     xml.Name         `xml:"urn:SAPControl ICMGetConnectionListResponse"`
+	Connections []*ICMConnection `xml:"connection>item,omitempty" json:"connection>item,omitempty"`
+}
+type ICMConnection struct {
+	Conid            string `xml:"conid,omitempty" json:"conid,omitempty"`
+	Protocol         string `xml:"protocol,omitempty" json:"protocol,omitempty"`
+	Role             string `xml:"role,omitempty" json:"role,omitempty"`
+	Requesttype      string `xml:"requesttype,omitempty" json:"requesttype,omitempty"`
+	Peeraddress      string `xml:"peer-address,omitempty" json:"peer-address,omitempty"`
+	Peerport         int32  `xml:"peer-port,omitempty" json:"peer-port,omitempty"`
+	Localaddress     string `xml:"local-address,omitempty" json:"local-address,omitempty"`
+	Localport        int32  `xml:"local-port,omitempty" json:"local-port,omitempty"`
+	Proctimeout      int32  `xml:"proc-timeout,omitempty" json:"proc-timeout,omitempty"`
+	Keepalivetimeout int32  `xml:"keepalive-timeout,omitempty" json:"keepalive-timeout,omitempty"`
+	Connectiontime   string `xml:"connection-time,omitempty" json:"connection-time,omitempty"`
+	Nihdl            int32  `xml:"nihdl,omitempty" json:"nihdl,omitempty"`
+}
+
+type ICMGetCacheEntries struct {
+	XMLName xml.Name `xml:"urn:SAPControl ICMGetCacheEntries"`
+}
+type ICMGetCacheEntriesResponse struct {
+	XMLName xml.Name         `xml:"urn:SAPControl ICMGetCacheEntriesResponse"`
+	Entries []*ICMCacheEntry `xml:"entry>item,omitempty" json:"entry>item,omitempty"`
+}
+type ICMCacheEntry struct {
+	Name           string `xml:"name,omitempty" json:"name,omitempty"`
+	Version        int32  `xml:"version,omitempty" json:"version,omitempty"`
+	Size           int64  `xml:"size,omitempty" json:"size,omitempty"`
+	Valid          bool   `xml:"valid,omitempty" json:"valid,omitempty"`
+	Cache          string `xml:"cache,omitempty" json:"cache,omitempty"`
+	Creationtime   string `xml:"creation-time,omitempty" json:"creation-time,omitempty"`
+	Lastaccesstime string `xml:"last-access-time,omitempty" json:"last-access-time,omitempty"`
+	Expirationtime string `xml:"expiration-time,omitempty" json:"expiration-time,omitempty"`
+	Cacheurl       string `xml:"cacheurl,omitempty" json:"cacheurl,omitempty"`
+}
+
+type WebDispGetServerList struct {
+	XMLName xml.Name `xml:"urn:SAPControl WebDispGetServerList"`
+}
+type WebDispGetServerListResponse struct {
+	XMLName xml.Name         `xml:"urn:SAPControl WebDispGetServerListResponse"`
+	Servers []*WebDispServer `xml:"server>item,omitempty" json:"server>item,omitempty"`
+}
+type WebDispServer struct {
+	Sid             string `xml:"sid,omitempty" json:"sid,omitempty"`
+	Instance        string `xml:"instance,omitempty" json:"instance,omitempty"`
+	Hostname        string `xml:"hostname,omitempty" json:"hostname,omitempty"`
+	Protocol        string `xml:"protocol,omitempty" json:"protocol,omitempty"`
+	Type            string `xml:"type,omitempty" json:"type,omitempty"`
+	Status          string `xml:"status,omitempty" json:"status,omitempty"`
+	Capacity        int32  `xml:"capacity,omitempty" json:"capacity,omitempty"`
+	Load            int32  `xml:"load,omitempty" json:"load,omitempty"`
+	Port            int32  `xml:"port,omitempty" json:"port,omitempty"`
+	Curconn         int32  `xml:"cur-conn,omitempty" json:"cur-conn,omitempty"`
+	Peakconn        int32  `xml:"peak-conn,omitempty" json:"peak-conn,omitempty"`
+	Maxconn         int32  `xml:"max-conn,omitempty" json:"max-conn,omitempty"`
+	Secport         int32  `xml:"sec-port,omitempty" json:"sec-port,omitempty"`
+	Seccurconn      int32  `xml:"sec-cur-conn,omitempty" json:"sec-cur-conn,omitempty"`
+	Secpeakconn     int32  `xml:"sec-peak-conn,omitempty" json:"sec-peak-conn,omitempty"`
+	Secmaxconn      int32  `xml:"sec-max-conn,omitempty" json:"sec-max-conn,omitempty"`
+	Reqcntstateless int64  `xml:"req-cnt-stateless,omitempty" json:"req-cnt-stateless,omitempty"`
+	Reqcntstateful  int64  `xml:"req-cnt-stateful,omitempty" json:"req-cnt-stateful,omitempty"`
+	Reqcntgroup     int64  `xml:"req-cnt-group,omitempty" json:"req-cnt-group,omitempty"`
+	Resptimemin     int64  `xml:"resp-time-min,omitempty" json:"resp-time-min,omitempty"`
+	Resptimeavg     int64  `xml:"resp-time-avg,omitempty" json:"resp-time-avg,omitempty"`
+	Resptimelast    int64  `xml:"resp-time-last,omitempty" json:"resp-time-last,omitempty"`
+	Pingtimelast    int64  `xml:"ping-time-last,omitempty" json:"ping-time-last,omitempty"`
+}
+
+type HACheckConfig struct {
+	XMLName xml.Name `xml:"urn:SAPControl HACheckConfig"`
+}
+type HACheckConfigResponse struct {
+	XMLName xml.Name   `xml:"urn:SAPControl HACheckConfigResponse"`
+	Checks  []*HACheck `xml:"check>item,omitempty" json:"check>item,omitempty"`
+}
+type HACheckFailoverConfig struct {
+	XMLName xml.Name `xml:"urn:SAPControl HACheckFailoverConfig"`
+}
+type HACheckFailoverConfigResponse struct {
+	XMLName xml.Name   `xml:"urn:SAPControl HACheckFailoverConfigResponse"`
+	Checks  []*HACheck `xml:"check>item,omitempty" json:"check>item,omitempty"`
+}
+type HACheck struct {
+	State       HAVerificationState `xml:"state,omitempty" json:"state,omitempty"`
+	Category    string              `xml:"category,omitempty" json:"category,omitempty"`
+	Description string              `xml:"description,omitempty" json:"description,omitempty"`
+	Comment     string              `xml:"comment,omitempty" json:"comment,omitempty"`
+}
+type HAGetFailoverConfig struct {
+	XMLName xml.Name `xml:"urn:SAPControl HAGetFailoverConfig"`
+}
+type HAGetFailoverConfigResponse struct {
+	XMLName               xml.Name `xml:"urn:SAPControl HAGetFailoverConfigResponse"`
+	HAActive              bool     `xml:"HAActive,omitempty" json:"HAActive,omitempty"`
+	HAProductVersion      string   `xml:"HAProductVersion,omitempty" json:"HAProductVersion,omitempty"`
+	HASAPInterfaceVersion string   `xml:"HASAPInterfaceVersion,omitempty" json:"HASAPInterfaceVersion,omitempty"`
+	HADocumentation       string   `xml:"HADocumentation,omitempty" json:"HADocumentation,omitempty"`
+	HAActiveNode          string   `xml:"HAActiveNode,omitempty" json:"HAActiveNode,omitempty"`
+	HANodes               []string `xml:"HANodes>item,omitempty" json:"HANodes>item,omitempty"`
+}
+
+type J2EEGetVMHeapInfo struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetVMHeapInfo"`
+}
+type J2EEGetVMHeapInfoResponse struct {
+	XMLName xml.Name    `xml:"urn:SAPControl J2EEGetVMHeapInfoResponse"`
+	Heaps   []*HeapInfo `xml:"heap>item,omitempty" json:"heap>item,omitempty"`
+}
+type HeapInfo struct {
+	Processname string     `xml:"processname,omitempty" json:"processname,omitempty"`
+	Type        string     `xml:"type,omitempty" json:"type,omitempty"`
+	Size        int64      `xml:"size,omitempty" json:"size,omitempty"`
+	CommitSize  int64      `xml:"commitSize,omitempty" json:"commitSize,omitempty"`
+	MaxUsedSize int64      `xml:"maxUsedSize,omitempty" json:"maxUsedSize,omitempty"`
+	InitialSize int64      `xml:"initialSize,omitempty" json:"initialSize,omitempty"`
+	MaxSize     int64      `xml:"maxSize,omitempty" json:"maxSize,omitempty"`
+	Dispstatus  STATECOLOR `xml:"dispstatus,omitempty" json:"dispstatus,omitempty"`
+}
+
+type J2EEGetVMGCHistory struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetVMGCHistory"`
+}
+type J2EEGetVMGCHistoryResponse struct {
+	XMLName xml.Name  `xml:"urn:SAPControl J2EEGetVMGCHistoryResponse"`
+	GCs     []*GCInfo `xml:"gc>item,omitempty" json:"gc>item,omitempty"`
+}
+type GCInfo struct {
+	Processname     string `xml:"processname,omitempty" json:"processname,omitempty"`
+	Type            string `xml:"type,omitempty" json:"type,omitempty"`
+	Reason          string `xml:"reason,omitempty" json:"reason,omitempty"`
+	StartTime       string `xml:"startTime,omitempty" json:"startTime,omitempty"`
+	Duration        int32  `xml:"duration,omitempty" json:"duration,omitempty"`
+	CpuTime         int32  `xml:"cpuTime,omitempty" json:"cpuTime,omitempty"`
+	ObjBytesBefore  int64  `xml:"objBytesBefore,omitempty" json:"objBytesBefore,omitempty"`
+	ObjBytesAfter   int64  `xml:"objBytesAfter,omitempty" json:"objBytesAfter,omitempty"`
+	ObjBytesFreed   int64  `xml:"objBytesFreed,omitempty" json:"objBytesFreed,omitempty"`
+	ClsBytesBefore  int64  `xml:"clsBytesBefore,omitempty" json:"clsBytesBefore,omitempty"`
+	ClsBytesAfter   int64  `xml:"clsBytesAfter,omitempty" json:"clsBytesAfter,omitempty"`
+	ClsBytesFreed   int64  `xml:"clsBytesFreed,omitempty" json:"clsBytesFreed,omitempty"`
+	HeapSize        int64  `xml:"heapSize,omitempty" json:"heapSize,omitempty"`
+	UnloadedClasses int32  `xml:"unloadedClasses,omitempty" json:"unloadedClasses,omitempty"`
+}
+
+type J2EEGetProcessList struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetProcessList"`
+}
+type J2EEGetProcessListResponse struct {
+	XMLName   xml.Name       `xml:"urn:SAPControl J2EEGetProcessListResponse"`
+	Processes []*J2EEProcess `xml:"process>item,omitempty" json:"process>item,omitempty"`
+}
+type J2EEProcess struct {
+	TelnetPort   int32      `xml:"telnetPort,omitempty" json:"telnetPort,omitempty"`
+	Name         string     `xml:"name,omitempty" json:"name,omitempty"`
+	Pid          int32      `xml:"pid,omitempty" json:"pid,omitempty"`
+	Type         string     `xml:"type,omitempty" json:"type,omitempty"`
+	Restart      string     `xml:"restart,omitempty" json:"restart,omitempty"`
+	ExitCode     string     `xml:"exitCode,omitempty" json:"exitCode,omitempty"`
+	State        J2EEPSTATE `xml:"state,omitempty" json:"state,omitempty"`
+	Statetext    string     `xml:"statetext,omitempty" json:"statetext,omitempty"`
+	StartTime    string     `xml:"startTime,omitempty" json:"startTime,omitempty"`
+	ElapsedTime  string     `xml:"elapsedTime,omitempty" json:"elapsedTime,omitempty"`
+	RestartCount int32      `xml:"restartCount,omitempty" json:"restartCount,omitempty"`
+	ErrorCount   int32      `xml:"errorCount,omitempty" json:"errorCount,omitempty"`
+	Cpu          string     `xml:"cpu,omitempty" json:"cpu,omitempty"`
+	Debug        string     `xml:"debug,omitempty" json:"debug,omitempty"`
+}
+
+type J2EEGetThreadList struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetThreadList"`
+}
+type J2EEGetThreadListResponse struct {
+	XMLName xml.Name      `xml:"urn:SAPControl J2EEGetThreadListResponse"`
+	Threads []*J2EEThread `xml:"thread>item,omitempty" json:"thread>item,omitempty"`
+}
+type J2EEThread struct {
+	Processname       string     `xml:"processname,omitempty" json:"processname,omitempty"`
+	StartTime         string     `xml:"startTime,omitempty" json:"startTime,omitempty"`
+	UpdateTime        string     `xml:"updateTime,omitempty" json:"updateTime,omitempty"`
+	TaskupdateTime    string     `xml:"taskupdateTime,omitempty" json:"taskupdateTime,omitempty"`
+	SubtaskupdateTime string     `xml:"subtaskupdateTime,omitempty" json:"subtaskupdateTime,omitempty"`
+	Task              string     `xml:"task,omitempty" json:"task,omitempty"`
+	Subtask           string     `xml:"subtask,omitempty" json:"subtask,omitempty"`
+	Name              string     `xml:"name,omitempty" json:"name,omitempty"`
+	Classname         string     `xml:"classname,omitempty" json:"classname,omitempty"`
+	User              string     `xml:"user,omitempty" json:"user,omitempty"`
+	Pool              string     `xml:"pool,omitempty" json:"pool,omitempty"`
+	State             string     `xml:"state,omitempty" json:"state,omitempty"`
+	Dispstatus        STATECOLOR `xml:"dispstatus,omitempty" json:"dispstatus,omitempty"`
+}
+
+type J2EEGetWebSessionList2 struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetWebSessionList2"`
+}
+type J2EEGetWebSessionList2Response struct {
+	XMLName  xml.Name           `xml:"urn:SAPControl J2EEGetWebSessionList2Response"`
+	Sessions []*J2EEWebSession2 `xml:"session>item,omitempty" json:"session>item,omitempty"`
+}
+type J2EEWebSession2 struct {
+	Processname    string `xml:"processname,omitempty" json:"processname,omitempty"`
+	IdHash         int32  `xml:"IdHash,omitempty" json:"IdHash,omitempty"`
+	Size           int32  `xml:"size,omitempty" json:"size,omitempty"`
+	Timeout        int32  `xml:"timeout,omitempty" json:"timeout,omitempty"`
+	ActiveRequests int32  `xml:"activeRequests,omitempty" json:"activeRequests,omitempty"`
+	StartTime      string `xml:"startTime,omitempty" json:"startTime,omitempty"`
+	UpdateTime     string `xml:"updateTime,omitempty" json:"updateTime,omitempty"`
+	State          string `xml:"state,omitempty" json:"state,omitempty"`
+	BackingStore   string `xml:"backingStore,omitempty" json:"backingStore,omitempty"`
+	User           string `xml:"user,omitempty" json:"user,omitempty"`
+	AppName        string `xml:"AppName,omitempty" json:"AppName,omitempty"`
+}
+type J2EEGetEJBSessionList struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetEJBSessionList"`
+}
+type J2EEGetEJBSessionListResponse struct {
+	XMLName  xml.Name          `xml:"urn:SAPControl J2EEGetEJBSessionListResponse"`
+	Sessions []*J2EEEJBSession `xml:"ejbsession>item,omitempty" json:"ejbsession>item,omitempty"`
+}
+type J2EEEJBSession struct {
+	IdHash         int32  `xml:"IdHash,omitempty" json:"IdHash,omitempty"`
+	State          string `xml:"state,omitempty" json:"state,omitempty"`
+	Size           int32  `xml:"size,omitempty" json:"size,omitempty"`
+	ActiveRequests int32  `xml:"activeRequests,omitempty" json:"activeRequests,omitempty"`
+	TotalRequests  int32  `xml:"totalRequests,omitempty" json:"totalRequests,omitempty"`
+	BackingStore   string `xml:"backingStore,omitempty" json:"backingStore,omitempty"`
+	Processname    string `xml:"processname,omitempty" json:"processname,omitempty"`
+	StartTime      string `xml:"startTime,omitempty" json:"startTime,omitempty"`
+	UpdateTime     string `xml:"updateTime,omitempty" json:"updateTime,omitempty"`
+	ResponseTime   int32  `xml:"responseTime,omitempty" json:"responseTime,omitempty"`
+	User           string `xml:"user,omitempty" json:"user,omitempty"`
+	Transaction    string `xml:"transaction,omitempty" json:"transaction,omitempty"`
+	Ejb            string `xml:"ejb,omitempty" json:"ejb,omitempty"`
+	Application    string `xml:"application,omitempty" json:"application,omitempty"`
+	Reference      string `xml:"reference,omitempty" json:"reference,omitempty"`
+}
+type J2EEGetSessionList struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetSessionList"`
+}
+type J2EEGetSessionListResponse struct {
+	XMLName  xml.Name       `xml:"urn:SAPControl J2EEGetSessionListResponse"`
+	Sessions []*J2EESession `xml:"session>item,omitempty" json:"session>item,omitempty"`
+}
+type J2EESession struct {
+	Processname    string `xml:"processname,omitempty" json:"processname,omitempty"`
+	IdHash         int32  `xml:"IdHash,omitempty" json:"IdHash,omitempty"`
+	Size           int32  `xml:"size,omitempty" json:"size,omitempty"`
+	Timeout        int32  `xml:"timeout,omitempty" json:"timeout,omitempty"`
+	ActiveRequests int32  `xml:"activeRequests,omitempty" json:"activeRequests,omitempty"`
+	StartTime      string `xml:"startTime,omitempty" json:"startTime,omitempty"`
+	UpdateTime     string `xml:"updateTime,omitempty" json:"updateTime,omitempty"`
+	Sticky         string `xml:"sticky,omitempty" json:"sticky,omitempty"`
+	Corrupt        string `xml:"corrupt,omitempty" json:"corrupt,omitempty"`
+	BackingStore   string `xml:"backingStore,omitempty" json:"backingStore,omitempty"`
+}
+
+type J2EEGetCacheStatistic struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetCacheStatistic"`
+}
+type J2EEGetCacheStatisticResponse struct {
+	XMLName xml.Name     `xml:"urn:SAPControl J2EEGetCacheStatisticResponse"`
+	Caches  []*J2EECache `xml:"cache>item,omitempty" json:"cache>item,omitempty"`
+}
+type J2EECache struct {
+	Cachename             string     `xml:"cachename,omitempty" json:"cachename,omitempty"`
+	Processname           string     `xml:"processname,omitempty" json:"processname,omitempty"`
+	Type                  string     `xml:"type,omitempty" json:"type,omitempty"`
+	Size                  int64      `xml:"size,omitempty" json:"size,omitempty"`
+	AttrSize              int64      `xml:"attrSize,omitempty" json:"attrSize,omitempty"`
+	KeysSize              int64      `xml:"keysSize,omitempty" json:"keysSize,omitempty"`
+	CachedObjects         int32      `xml:"cachedObjects,omitempty" json:"cachedObjects,omitempty"`
+	UsedObjects           int32      `xml:"usedObjects,omitempty" json:"usedObjects,omitempty"`
+	Puts                  int32      `xml:"puts,omitempty" json:"puts,omitempty"`
+	Gets                  int32      `xml:"gets,omitempty" json:"gets,omitempty"`
+	Hits                  int32      `xml:"hits,omitempty" json:"hits,omitempty"`
+	Changes               int32      `xml:"changes,omitempty" json:"changes,omitempty"`
+	Removes               int32      `xml:"removes,omitempty" json:"removes,omitempty"`
+	Evictions             int32      `xml:"evictions,omitempty" json:"evictions,omitempty"`
+	InstanceInvalidations int32      `xml:"instanceInvalidations,omitempty" json:"instanceInvalidations,omitempty"`
+	ClusterInvalidations  int32      `xml:"clusterInvalidations,omitempty" json:"clusterInvalidations,omitempty"`
+	UpdateTime            string     `xml:"updateTime,omitempty" json:"updateTime,omitempty"`
+	Dispstatus            STATECOLOR `xml:"dispstatus,omitempty" json:"dispstatus,omitempty"`
+}
+type J2EEGetSharedTableInfo struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetSharedTableInfo"`
+}
+type J2EEGetSharedTableInfoResponse struct {
+	XMLName xml.Name               `xml:"urn:SAPControl J2EEGetSharedTableInfoResponse"`
+	Tables  []*J2EESharedTableInfo `xml:"jsf>item,omitempty" json:"jsf>item,omitempty"`
+}
+type J2EESharedTableInfo struct {
+	Table      string     `xml:"table,omitempty" json:"table,omitempty"`
+	Used       int32      `xml:"used,omitempty" json:"used,omitempty"`
+	Peak       int32      `xml:"peak,omitempty" json:"peak,omitempty"`
+	Limit      int32      `xml:"limit,omitempty" json:"limit,omitempty"`
+	Dispstatus STATECOLOR `xml:"dispstatus,omitempty" json:"dispstatus,omitempty"`
+}
+
+type J2EEGetClusterMsgList struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetClusterMsgList"`
+}
+type J2EEGetClusterMsgListResponse struct {
+	XMLName  xml.Name          `xml:"urn:SAPControl J2EEGetClusterMsgListResponse"`
+	Messages []*J2EEClusterMsg `xml:"msg>item,omitempty" json:"msg>item,omitempty"`
+}
+type J2EEClusterMsg struct {
+	Service               string `xml:"service,omitempty" json:"service,omitempty"`
+	Id                    string `xml:"id,omitempty" json:"id,omitempty"`
+	Count                 int64  `xml:"count,omitempty" json:"count,omitempty"`
+	Length                int64  `xml:"length,omitempty" json:"length,omitempty"`
+	Avglength             int64  `xml:"avg-length,omitempty" json:"avg-length,omitempty"`
+	Maxlength             int64  `xml:"max-length,omitempty" json:"max-length,omitempty"`
+	Countp2pmsg           int64  `xml:"count-p2p-msg,omitempty" json:"count-p2p-msg,omitempty"`
+	Countp2prequest       int64  `xml:"count-p2p-request,omitempty" json:"count-p2p-request,omitempty"`
+	Countp2preply         int64  `xml:"count-p2p-reply,omitempty" json:"count-p2p-reply,omitempty"`
+	Countbroadcastmsg     int64  `xml:"count-broadcast-msg,omitempty" json:"count-broadcast-msg,omitempty"`
+	Countbroadcastrequest int64  `xml:"count-broadcast-request,omitempty" json:"count-broadcast-request,omitempty"`
+}
+type J2EEGetComponentList struct {
+	XMLName xml.Name `xml:"urn:SAPControl J2EEGetComponentList"`
+}
+type J2EEGetComponentListResponse struct {
+	XMLName    xml.Name             `xml:"urn:SAPControl J2EEGetComponentListResponse"`
+	Components []*J2EEComponentInfo `xml:"component>item,omitempty" json:"component>item,omitempty"`
+}
+type J2EEComponentInfo struct {
+	Type           string     `xml:"type,omitempty" json:"type,omitempty"`
+	Name           string     `xml:"name,omitempty" json:"name,omitempty"`
+	Startupmode    string     `xml:"startupmode,omitempty" json:"startupmode,omitempty"`
+	Status         string     `xml:"status,omitempty" json:"status,omitempty"`
+	Expectedstatus string     `xml:"expectedstatus,omitempty" json:"expectedstatus,omitempty"`
+	Details        string     `xml:"details,omitempty" json:"details,omitempty"`
+	Dispstatus     STATECOLOR `xml:"dispstatus,omitempty" json:"dispstatus,omitempty"`
+}
+
 type webService struct {
 	Client *MyClient
 	//once               *sync.Once
@@ -290,6 +837,22 @@ func (s *webService) GetInstanceProperties(ctx context.Context, endpoint string)
 	return response, nil
 }
 
+// implements WebService.GetVersionInfo(context.Context, string)
+func (s *webService) GetVersionInfo(ctx context.Context, endpoint string) (*GetVersionInfoResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &GetVersionInfo{}
+	response := &GetVersionInfoResponse{}
+
+	err := client.CallContext(ctx, "GetVersionInfo", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("GetVersionInfo: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
 // implements WebService.GetProcessList(context.Context, string)
 func (s *webService) GetProcessList(ctx context.Context, endpoint string) (*GetProcessListResponse, error) {
 	c := s.Client
@@ -324,6 +887,22 @@ func (s *webService) EnqGetStatistic(ctx context.Context, endpoint string) (*Enq
 	return response, nil
 }
 
+// implements WebService.EnqGetLockTable(context.Context, string)
+func (s *webService) EnqGetLockTable(ctx context.Context, endpoint string) (*EnqGetLockTableResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &EnqGetLockTable{}
+	response := &EnqGetLockTableResponse{}
+
+	err := client.CallContext(ctx, "EnqGetLockTable", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("EnqGetLockTable: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
 // implements WebService.GetQueueStatistic(context.Context, string)
 func (s *webService) GetQueueStatistic(ctx context.Context, endpoint string) (*GetQueueStatisticResponse, error) {
 	c := s.Client
@@ -358,6 +937,55 @@ func (s *webService) ABAPGetWPTable(ctx context.Context, endpoint string) (*ABAP
 	return response, nil
 }
 
+// implements WebService.ABAPGetSystemWPTable(context.Context, string)
+func (s *webService) ABAPGetSystemWPTable(ctx context.Context, endpoint string) (*ABAPGetSystemWPTableResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	// all work processes, the waiting ones are counted too
+	request := &ABAPGetSystemWPTable{Activeonly: false}
+	response := &ABAPGetSystemWPTableResponse{}
+
+	err := client.CallContext(ctx, "ABAPGetSystemWPTable", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ABAPGetSystemWPTable: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.ABAPGetComponentList(context.Context, string)
+func (s *webService) ABAPGetComponentList(ctx context.Context, endpoint string) (*ABAPGetComponentListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &ABAPGetComponentList{}
+	response := &ABAPGetComponentListResponse{}
+
+	err := client.CallContext(ctx, "ABAPGetComponentList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ABAPGetComponentList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.ABAPCheckRFCDestinations(context.Context, string)
+func (s *webService) ABAPCheckRFCDestinations(ctx context.Context, endpoint string) (*ABAPCheckRFCDestinationsResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &ABAPCheckRFCDestinations{}
+	response := &ABAPCheckRFCDestinationsResponse{}
+
+	err := client.CallContext(ctx, "ABAPCheckRFCDestinations", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ABAPCheckRFCDestinations: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
 // implements WebService.GetAlerts(context.Context, string)
 func (s *webService) GetAlerts(ctx context.Context, endpoint string) (*GetAlertsResponse, error) {
 	c := s.Client
@@ -375,6 +1003,326 @@ func (s *webService) GetAlerts(ctx context.Context, endpoint string) (*GetAlerts
 	return response, nil
 }
 
+// implements WebService.GetAlertTree(context.Context, string)
+func (s *webService) GetAlertTree(ctx context.Context, endpoint string) (*GetAlertTreeResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &GetAlertTree{}
+	response := &GetAlertTreeResponse{}
+
+	err := client.CallContext(ctx, "GetAlertTree", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("GetAlertTree: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.ABAPReadSyslog(context.Context, string)
+func (s *webService) ABAPReadSyslog(ctx context.Context, endpoint string) (*ABAPReadSyslogResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &ABAPReadSyslog{}
+	response := &ABAPReadSyslogResponse{}
+
+	err := client.CallContext(ctx, "ABAPReadSyslog", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ABAPReadSyslog: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.ICMGetThreadList(context.Context, string)
+func (s *webService) ICMGetThreadList(ctx context.Context, endpoint string) (*ICMGetThreadListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &ICMGetThreadList{}
+	response := &ICMGetThreadListResponse{}
+
+	err := client.CallContext(ctx, "ICMGetThreadList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ICMGetThreadList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.ICMGetConnectionList(context.Context, string)
+func (s *webService) ICMGetConnectionList(ctx context.Context, endpoint string) (*ICMGetConnectionListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &ICMGetConnectionList{}
+	response := &ICMGetConnectionListResponse{}
+
+	err := client.CallContext(ctx, "ICMGetConnectionList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ICMGetConnectionList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.ICMGetCacheEntries(context.Context, string)
+func (s *webService) ICMGetCacheEntries(ctx context.Context, endpoint string) (*ICMGetCacheEntriesResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &ICMGetCacheEntries{}
+	response := &ICMGetCacheEntriesResponse{}
+
+	err := client.CallContext(ctx, "ICMGetCacheEntries", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("ICMGetCacheEntries: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.WebDispGetServerList(context.Context, string)
+func (s *webService) WebDispGetServerList(ctx context.Context, endpoint string) (*WebDispGetServerListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &WebDispGetServerList{}
+	response := &WebDispGetServerListResponse{}
+
+	err := client.CallContext(ctx, "WebDispGetServerList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("WebDispGetServerList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.HACheckConfig(context.Context, string)
+func (s *webService) HACheckConfig(ctx context.Context, endpoint string) (*HACheckConfigResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &HACheckConfig{}
+	response := &HACheckConfigResponse{}
+
+	err := client.CallContext(ctx, "HACheckConfig", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("HACheckConfig: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.HACheckFailoverConfig(context.Context, string)
+func (s *webService) HACheckFailoverConfig(ctx context.Context, endpoint string) (*HACheckFailoverConfigResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &HACheckFailoverConfig{}
+	response := &HACheckFailoverConfigResponse{}
+
+	err := client.CallContext(ctx, "HACheckFailoverConfig", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("HACheckFailoverConfig: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.HAGetFailoverConfig(context.Context, string)
+func (s *webService) HAGetFailoverConfig(ctx context.Context, endpoint string) (*HAGetFailoverConfigResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &HAGetFailoverConfig{}
+	response := &HAGetFailoverConfigResponse{}
+
+	err := client.CallContext(ctx, "HAGetFailoverConfig", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("HAGetFailoverConfig: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetVMHeapInfo(context.Context, string)
+func (s *webService) J2EEGetVMHeapInfo(ctx context.Context, endpoint string) (*J2EEGetVMHeapInfoResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetVMHeapInfo{}
+	response := &J2EEGetVMHeapInfoResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetVMHeapInfo", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetVMHeapInfo: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetVMGCHistory(context.Context, string)
+func (s *webService) J2EEGetVMGCHistory(ctx context.Context, endpoint string) (*J2EEGetVMGCHistoryResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetVMGCHistory{}
+	response := &J2EEGetVMGCHistoryResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetVMGCHistory", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetVMGCHistory: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetProcessList(context.Context, string)
+func (s *webService) J2EEGetProcessList(ctx context.Context, endpoint string) (*J2EEGetProcessListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetProcessList{}
+	response := &J2EEGetProcessListResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetProcessList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetProcessList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetThreadList(context.Context, string)
+func (s *webService) J2EEGetThreadList(ctx context.Context, endpoint string) (*J2EEGetThreadListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetThreadList{}
+	response := &J2EEGetThreadListResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetThreadList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetThreadList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetWebSessionList2(context.Context, string)
+func (s *webService) J2EEGetWebSessionList2(ctx context.Context, endpoint string) (*J2EEGetWebSessionList2Response, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetWebSessionList2{}
+	response := &J2EEGetWebSessionList2Response{}
+
+	err := client.CallContext(ctx, "J2EEGetWebSessionList2", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetWebSessionList2: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetEJBSessionList(context.Context, string)
+func (s *webService) J2EEGetEJBSessionList(ctx context.Context, endpoint string) (*J2EEGetEJBSessionListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetEJBSessionList{}
+	response := &J2EEGetEJBSessionListResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetEJBSessionList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetEJBSessionList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetSessionList(context.Context, string)
+func (s *webService) J2EEGetSessionList(ctx context.Context, endpoint string) (*J2EEGetSessionListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetSessionList{}
+	response := &J2EEGetSessionListResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetSessionList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetSessionList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetCacheStatistic(context.Context, string)
+func (s *webService) J2EEGetCacheStatistic(ctx context.Context, endpoint string) (*J2EEGetCacheStatisticResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetCacheStatistic{}
+	response := &J2EEGetCacheStatisticResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetCacheStatistic", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetCacheStatistic: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetSharedTableInfo(context.Context, string)
+func (s *webService) J2EEGetSharedTableInfo(ctx context.Context, endpoint string) (*J2EEGetSharedTableInfoResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetSharedTableInfo{}
+	response := &J2EEGetSharedTableInfoResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetSharedTableInfo", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetSharedTableInfo: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetClusterMsgList(context.Context, string)
+func (s *webService) J2EEGetClusterMsgList(ctx context.Context, endpoint string) (*J2EEGetClusterMsgListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetClusterMsgList{}
+	response := &J2EEGetClusterMsgListResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetClusterMsgList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetClusterMsgList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
+// implements WebService.J2EEGetComponentList(context.Context, string)
+func (s *webService) J2EEGetComponentList(ctx context.Context, endpoint string) (*J2EEGetComponentListResponse, error) {
+	c := s.Client
+	endpoint = fmt.Sprintf("%s%s", endpoint, c.config.Viper.GetString("sap_control_access_point"))
+	client := c.CreateSoapClient(endpoint)
+
+	request := &J2EEGetComponentList{}
+	response := &J2EEGetComponentListResponse{}
+
+	err := client.CallContext(ctx, "J2EEGetComponentList", request, response)
+	if err != nil {
+		return nil, fmt.Errorf("J2EEGetComponentList: endpoint=%s, err=%v", endpoint, err)
+	}
+	return response, nil
+}
+
 // makes the STATECOLOR values more metric friendly
 func StateColorToFloat(statecolor STATECOLOR) (float64, error) {
 	switch statecolor {
@@ -391,6 +1339,36 @@ func StateColorToFloat(statecolor STATECOLOR) (float64, error) {
 	}
 }
 
+// makes the HA check states metric friendly, using the same codes as the matching STATECOLOR
+func HAStateToFloat(state HAVerificationState) (float64, error) {
+	switch state {
+	case HA_VERIFICATION_STATE_SUCCESS:
+		return float64(STATECOLOR_CODE_GREEN), nil
+	case HA_VERIFICATION_STATE_WARNING:
+		return float64(STATECOLOR_CODE_YELLOW), nil
+	case HA_VERIFICATION_STATE_ERROR:
+		return float64(STATECOLOR_CODE_RED), nil
+	default:
+		return 0, errors.New("Invalid HAVerificationState value")
+	}
+}
+
+// makes the J2EE process states metric friendly, using the codes of the matching STATECOLOR
+func J2EEStateToFloat(state J2EEPSTATE) (float64, error) {
+	switch state {
+	case J2EE_PSTATE_UNKNOWN:
+		return float64(STATECOLOR_CODE_GRAY), nil
+	case J2EE_PSTATE_RUNNING:
+		return float64(STATECOLOR_CODE_GREEN), nil
+	case J2EE_PSTATE_STARTING, J2EE_PSTATE_CORE_RUNNING, J2EE_PSTATE_STOPPING, J2EE_PSTATE_MAINTENANCE:
+		return float64(STATECOLOR_CODE_YELLOW), nil
+	case J2EE_PSTATE_STOPPED:
+		return float64(STATECOLOR_CODE_RED), nil
+	default:
+		return 0, errors.New("Invalid J2EEPSTATE value")
+	}
+}
+
 // makes the STATECOLOR values more metric friendly
 func StateColorToLevel(statecolor STATECOLOR) (string, error) {
 	switch statecolor {
@@ -436,6 +1414,27 @@ func Make_string_map(s string) map[string]string {
 	}
 	return m
 }
+
+// timestamp layouts used by the SAPControl web service methods
+var sapTimeLayouts = []string{
+	"2006 01 02 15:04:05",
+	"2006-01-02 15:04:05",
+	"02.01.2006 15:04:05",
+	"Mon Jan _2 15:04:05 2006",
+	"20060102150405",
+}
+
+// parse the SAPControl timestamp string, the timestamp does not carry a time zone, so it is interpreted in loc
+func ParseSAPTime(timeStr string, loc *time.Location) (time.Time, error) {
+	timeStr = strings.TrimSpace(timeStr)
+	for _, layout := range sapTimeLayouts {
+		if t, err := time.ParseInLocation(layout, timeStr, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("ParseSAPTime: unknown time format: %q", timeStr)
+}
+
 func ParceCPUTime(cpuStr string) (float64, error) {
 
 	if cpuStr == "" {
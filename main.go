@@ -41,6 +41,8 @@ func init() {
 	flag.String("host_domain", "", "Optional Domain name to make FQDN together with hostname, Recommended in case of SAP hostname is a sigle-word hostname.")
 	flag.String("tls_skip_verify", "no", "For HTTPS scheme, should certificates signed by unknown authority being ignored")
 	flag.String("alert_samples_max_age", "2h", "Oldest acceptable timestamp for Alert item (back since now()). Use \"-1s\" for unlim.")
+	flag.String("alert_state_file", "", "The path to the file keeping the open Alerts across restarts, so that they are not forwarded again. Not kept if empty.")
+	flag.Bool("alert_state_reset", false, "Discard the alert_state_file on startup, the open Alerts are forwarded again.")
 	flag.StringP("config", "c", "", "The path to a custom configuration file. NOTE: it must be in yaml format.")
 	flag.CommandLine.SortFlags = false
 
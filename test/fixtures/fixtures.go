@@ -0,0 +1,53 @@
+// Package fixtures holds the test setup shared by the collector tests.
+package fixtures
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vgrusdev/promtail-client/promtail"
+	"github.com/vgrusdev/sap_system_exporter/cache"
+	"github.com/vgrusdev/sap_system_exporter/internal/config"
+	"github.com/vgrusdev/sap_system_exporter/lib/sapcontrol"
+	"github.com/vgrusdev/sap_system_exporter/test/mock_sapcontrol"
+)
+
+// NewMockWebService returns a WebService mock whose client reads a fresh configuration,
+// with a 5s scrape_timeout and the given settings. No configuration defaults are set.
+func NewMockWebService(ctrl *gomock.Controller, settings map[string]interface{}) *mock_sapcontrol.MockWebService {
+	myConfig := &config.MyConfig{Viper: viper.New()}
+	myConfig.Viper.Set("scrape_timeout", "5s")
+	for key, value := range settings {
+		myConfig.Viper.Set(key, value)
+	}
+	myClient := sapcontrol.NewSoapClient(myConfig, cache.NewCacheManager(myConfig))
+
+	mockWebService := mock_sapcontrol.NewMockWebService(ctrl)
+	mockWebService.EXPECT().GetMyClient().Return(myClient).AnyTimes()
+	return mockWebService
+}
+
+// FakeLokiClient is a promtail.Client keeping the single entries in a buffered channel, in UTC.
+type FakeLokiClient struct {
+	single chan *promtail.SingleEntry
+}
+
+func NewFakeLokiClient(size int) *FakeLokiClient {
+	return &FakeLokiClient{single: make(chan *promtail.SingleEntry, size)}
+}
+
+func (f *FakeLokiClient) Chan() chan<- *promtail.PromtailStream { return nil }
+func (f *FakeLokiClient) Single() chan<- *promtail.SingleEntry  { return f.single }
+func (f *FakeLokiClient) Shutdown()                             {}
+func (f *FakeLokiClient) GetLocation() *time.Location           { return time.UTC }
+
+// Received returns the entries pushed since the last call.
+func (f *FakeLokiClient) Received() []*promtail.SingleEntry {
+	var entries []*promtail.SingleEntry
+	for len(f.single) > 0 {
+		entries = append(entries, <-f.single)
+	}
+	return entries
+}
@@ -10,6 +10,7 @@
 package mock_sapcontrol
 
 import (
+	context "context"
 	reflect "reflect"
 
 	promtail "github.com/vgrusdev/promtail-client/promtail"
@@ -41,64 +42,199 @@ func (m *MockWebService) EXPECT() *MockWebServiceMockRecorder {
 	return m.recorder
 }
 
+// ABAPCheckRFCDestinations mocks base method.
+func (m *MockWebService) ABAPCheckRFCDestinations(arg0 context.Context, arg1 string) (*sapcontrol.ABAPCheckRFCDestinationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABAPCheckRFCDestinations", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ABAPCheckRFCDestinationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABAPCheckRFCDestinations indicates an expected call of ABAPCheckRFCDestinations.
+func (mr *MockWebServiceMockRecorder) ABAPCheckRFCDestinations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABAPCheckRFCDestinations", reflect.TypeOf((*MockWebService)(nil).ABAPCheckRFCDestinations), arg0, arg1)
+}
+
+// ABAPGetComponentList mocks base method.
+func (m *MockWebService) ABAPGetComponentList(arg0 context.Context, arg1 string) (*sapcontrol.ABAPGetComponentListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABAPGetComponentList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ABAPGetComponentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABAPGetComponentList indicates an expected call of ABAPGetComponentList.
+func (mr *MockWebServiceMockRecorder) ABAPGetComponentList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABAPGetComponentList", reflect.TypeOf((*MockWebService)(nil).ABAPGetComponentList), arg0, arg1)
+}
+
+// ABAPGetSystemWPTable mocks base method.
+func (m *MockWebService) ABAPGetSystemWPTable(arg0 context.Context, arg1 string) (*sapcontrol.ABAPGetSystemWPTableResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABAPGetSystemWPTable", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ABAPGetSystemWPTableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABAPGetSystemWPTable indicates an expected call of ABAPGetSystemWPTable.
+func (mr *MockWebServiceMockRecorder) ABAPGetSystemWPTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABAPGetSystemWPTable", reflect.TypeOf((*MockWebService)(nil).ABAPGetSystemWPTable), arg0, arg1)
+}
+
+// ABAPGetWPTable mocks base method.
+func (m *MockWebService) ABAPGetWPTable(arg0 context.Context, arg1 string) (*sapcontrol.ABAPGetWPTableResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABAPGetWPTable", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ABAPGetWPTableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABAPGetWPTable indicates an expected call of ABAPGetWPTable.
+func (mr *MockWebServiceMockRecorder) ABAPGetWPTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABAPGetWPTable", reflect.TypeOf((*MockWebService)(nil).ABAPGetWPTable), arg0, arg1)
+}
+
+// ABAPReadSyslog mocks base method.
+func (m *MockWebService) ABAPReadSyslog(arg0 context.Context, arg1 string) (*sapcontrol.ABAPReadSyslogResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ABAPReadSyslog", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ABAPReadSyslogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ABAPReadSyslog indicates an expected call of ABAPReadSyslog.
+func (mr *MockWebServiceMockRecorder) ABAPReadSyslog(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ABAPReadSyslog", reflect.TypeOf((*MockWebService)(nil).ABAPReadSyslog), arg0, arg1)
+}
+
+// EnqGetLockTable mocks base method.
+func (m *MockWebService) EnqGetLockTable(arg0 context.Context, arg1 string) (*sapcontrol.EnqGetLockTableResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqGetLockTable", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.EnqGetLockTableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqGetLockTable indicates an expected call of EnqGetLockTable.
+func (mr *MockWebServiceMockRecorder) EnqGetLockTable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqGetLockTable", reflect.TypeOf((*MockWebService)(nil).EnqGetLockTable), arg0, arg1)
+}
+
 // EnqGetStatistic mocks base method.
-func (m *MockWebService) EnqGetStatistic() (*sapcontrol.EnqGetStatisticResponse, error) {
+func (m *MockWebService) EnqGetStatistic(arg0 context.Context, arg1 string) (*sapcontrol.EnqGetStatisticResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnqGetStatistic")
+	ret := m.ctrl.Call(m, "EnqGetStatistic", arg0, arg1)
 	ret0, _ := ret[0].(*sapcontrol.EnqGetStatisticResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EnqGetStatistic indicates an expected call of EnqGetStatistic.
-func (mr *MockWebServiceMockRecorder) EnqGetStatistic() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) EnqGetStatistic(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqGetStatistic", reflect.TypeOf((*MockWebService)(nil).EnqGetStatistic), arg0, arg1)
+}
+
+// GetAlertTree mocks base method.
+func (m *MockWebService) GetAlertTree(arg0 context.Context, arg1 string) (*sapcontrol.GetAlertTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertTree", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.GetAlertTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertTree indicates an expected call of GetAlertTree.
+func (mr *MockWebServiceMockRecorder) GetAlertTree(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqGetStatistic", reflect.TypeOf((*MockWebService)(nil).EnqGetStatistic))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertTree", reflect.TypeOf((*MockWebService)(nil).GetAlertTree), arg0, arg1)
 }
 
 // GetAlerts mocks base method.
-func (m *MockWebService) GetAlerts() (*sapcontrol.GetAlertsResponse, error) {
+func (m *MockWebService) GetAlerts(arg0 context.Context, arg1 string) (*sapcontrol.GetAlertsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAlerts")
+	ret := m.ctrl.Call(m, "GetAlerts", arg0, arg1)
 	ret0, _ := ret[0].(*sapcontrol.GetAlertsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAlerts indicates an expected call of GetAlerts.
-func (mr *MockWebServiceMockRecorder) GetAlerts() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) GetAlerts(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockWebService)(nil).GetAlerts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockWebService)(nil).GetAlerts), arg0, arg1)
+}
+
+// GetCachedInstanceList mocks base method.
+func (m *MockWebService) GetCachedInstanceList(arg0 context.Context) ([]sapcontrol.InstanceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedInstanceList", arg0)
+	ret0, _ := ret[0].([]sapcontrol.InstanceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedInstanceList indicates an expected call of GetCachedInstanceList.
+func (mr *MockWebServiceMockRecorder) GetCachedInstanceList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedInstanceList", reflect.TypeOf((*MockWebService)(nil).GetCachedInstanceList), arg0)
+}
+
+// GetCachedProcessList mocks base method.
+func (m *MockWebService) GetCachedProcessList(arg0 context.Context, arg1 string) ([]sapcontrol.ProcessInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedProcessList", arg0, arg1)
+	ret0, _ := ret[0].([]sapcontrol.ProcessInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedProcessList indicates an expected call of GetCachedProcessList.
+func (mr *MockWebServiceMockRecorder) GetCachedProcessList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedProcessList", reflect.TypeOf((*MockWebService)(nil).GetCachedProcessList), arg0, arg1)
 }
 
 // GetCurrentInstance mocks base method.
-func (m *MockWebService) GetCurrentInstance() (*sapcontrol.CurrentSapInstance, error) {
+func (m *MockWebService) GetCurrentInstance(arg0 context.Context, arg1 string) (*sapcontrol.InstanceProperties, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCurrentInstance")
-	ret0, _ := ret[0].(*sapcontrol.CurrentSapInstance)
+	ret := m.ctrl.Call(m, "GetCurrentInstance", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.InstanceProperties)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCurrentInstance indicates an expected call of GetCurrentInstance.
-func (mr *MockWebServiceMockRecorder) GetCurrentInstance() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) GetCurrentInstance(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentInstance", reflect.TypeOf((*MockWebService)(nil).GetCurrentInstance))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentInstance", reflect.TypeOf((*MockWebService)(nil).GetCurrentInstance), arg0, arg1)
 }
 
 // GetInstanceProperties mocks base method.
-func (m *MockWebService) GetInstanceProperties() (*sapcontrol.GetInstancePropertiesResponse, error) {
+func (m *MockWebService) GetInstanceProperties(arg0 context.Context, arg1 string) (*sapcontrol.GetInstancePropertiesResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInstanceProperties")
+	ret := m.ctrl.Call(m, "GetInstanceProperties", arg0, arg1)
 	ret0, _ := ret[0].(*sapcontrol.GetInstancePropertiesResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInstanceProperties indicates an expected call of GetInstanceProperties.
-func (mr *MockWebServiceMockRecorder) GetInstanceProperties() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) GetInstanceProperties(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceProperties", reflect.TypeOf((*MockWebService)(nil).GetInstanceProperties))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceProperties", reflect.TypeOf((*MockWebService)(nil).GetInstanceProperties), arg0, arg1)
 }
 
 // GetLokiClient mocks base method.
@@ -130,48 +266,318 @@ func (mr *MockWebServiceMockRecorder) GetMyClient() *gomock.Call {
 }
 
 // GetProcessList mocks base method.
-func (m *MockWebService) GetProcessList() (*sapcontrol.GetProcessListResponse, error) {
+func (m *MockWebService) GetProcessList(arg0 context.Context, arg1 string) (*sapcontrol.GetProcessListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProcessList")
+	ret := m.ctrl.Call(m, "GetProcessList", arg0, arg1)
 	ret0, _ := ret[0].(*sapcontrol.GetProcessListResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProcessList indicates an expected call of GetProcessList.
-func (mr *MockWebServiceMockRecorder) GetProcessList() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) GetProcessList(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessList", reflect.TypeOf((*MockWebService)(nil).GetProcessList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessList", reflect.TypeOf((*MockWebService)(nil).GetProcessList), arg0, arg1)
 }
 
 // GetQueueStatistic mocks base method.
-func (m *MockWebService) GetQueueStatistic() (*sapcontrol.GetQueueStatisticResponse, error) {
+func (m *MockWebService) GetQueueStatistic(arg0 context.Context, arg1 string) (*sapcontrol.GetQueueStatisticResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQueueStatistic")
+	ret := m.ctrl.Call(m, "GetQueueStatistic", arg0, arg1)
 	ret0, _ := ret[0].(*sapcontrol.GetQueueStatisticResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetQueueStatistic indicates an expected call of GetQueueStatistic.
-func (mr *MockWebServiceMockRecorder) GetQueueStatistic() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) GetQueueStatistic(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueStatistic", reflect.TypeOf((*MockWebService)(nil).GetQueueStatistic))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueStatistic", reflect.TypeOf((*MockWebService)(nil).GetQueueStatistic), arg0, arg1)
 }
 
 // GetSystemInstanceList mocks base method.
-func (m *MockWebService) GetSystemInstanceList() (*sapcontrol.GetSystemInstanceListResponse, error) {
+func (m *MockWebService) GetSystemInstanceList(arg0 context.Context) (*sapcontrol.GetSystemInstanceListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSystemInstanceList")
+	ret := m.ctrl.Call(m, "GetSystemInstanceList", arg0)
 	ret0, _ := ret[0].(*sapcontrol.GetSystemInstanceListResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSystemInstanceList indicates an expected call of GetSystemInstanceList.
-func (mr *MockWebServiceMockRecorder) GetSystemInstanceList() *gomock.Call {
+func (mr *MockWebServiceMockRecorder) GetSystemInstanceList(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemInstanceList", reflect.TypeOf((*MockWebService)(nil).GetSystemInstanceList), arg0)
+}
+
+// GetVersionInfo mocks base method.
+func (m *MockWebService) GetVersionInfo(arg0 context.Context, arg1 string) (*sapcontrol.GetVersionInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersionInfo", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.GetVersionInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersionInfo indicates an expected call of GetVersionInfo.
+func (mr *MockWebServiceMockRecorder) GetVersionInfo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersionInfo", reflect.TypeOf((*MockWebService)(nil).GetVersionInfo), arg0, arg1)
+}
+
+// HACheckConfig mocks base method.
+func (m *MockWebService) HACheckConfig(arg0 context.Context, arg1 string) (*sapcontrol.HACheckConfigResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HACheckConfig", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.HACheckConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HACheckConfig indicates an expected call of HACheckConfig.
+func (mr *MockWebServiceMockRecorder) HACheckConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HACheckConfig", reflect.TypeOf((*MockWebService)(nil).HACheckConfig), arg0, arg1)
+}
+
+// HACheckFailoverConfig mocks base method.
+func (m *MockWebService) HACheckFailoverConfig(arg0 context.Context, arg1 string) (*sapcontrol.HACheckFailoverConfigResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HACheckFailoverConfig", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.HACheckFailoverConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HACheckFailoverConfig indicates an expected call of HACheckFailoverConfig.
+func (mr *MockWebServiceMockRecorder) HACheckFailoverConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HACheckFailoverConfig", reflect.TypeOf((*MockWebService)(nil).HACheckFailoverConfig), arg0, arg1)
+}
+
+// HAGetFailoverConfig mocks base method.
+func (m *MockWebService) HAGetFailoverConfig(arg0 context.Context, arg1 string) (*sapcontrol.HAGetFailoverConfigResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HAGetFailoverConfig", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.HAGetFailoverConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HAGetFailoverConfig indicates an expected call of HAGetFailoverConfig.
+func (mr *MockWebServiceMockRecorder) HAGetFailoverConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HAGetFailoverConfig", reflect.TypeOf((*MockWebService)(nil).HAGetFailoverConfig), arg0, arg1)
+}
+
+// ICMGetCacheEntries mocks base method.
+func (m *MockWebService) ICMGetCacheEntries(arg0 context.Context, arg1 string) (*sapcontrol.ICMGetCacheEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ICMGetCacheEntries", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ICMGetCacheEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ICMGetCacheEntries indicates an expected call of ICMGetCacheEntries.
+func (mr *MockWebServiceMockRecorder) ICMGetCacheEntries(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemInstanceList", reflect.TypeOf((*MockWebService)(nil).GetSystemInstanceList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ICMGetCacheEntries", reflect.TypeOf((*MockWebService)(nil).ICMGetCacheEntries), arg0, arg1)
+}
+
+// ICMGetConnectionList mocks base method.
+func (m *MockWebService) ICMGetConnectionList(arg0 context.Context, arg1 string) (*sapcontrol.ICMGetConnectionListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ICMGetConnectionList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ICMGetConnectionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ICMGetConnectionList indicates an expected call of ICMGetConnectionList.
+func (mr *MockWebServiceMockRecorder) ICMGetConnectionList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ICMGetConnectionList", reflect.TypeOf((*MockWebService)(nil).ICMGetConnectionList), arg0, arg1)
+}
+
+// ICMGetThreadList mocks base method.
+func (m *MockWebService) ICMGetThreadList(arg0 context.Context, arg1 string) (*sapcontrol.ICMGetThreadListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ICMGetThreadList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.ICMGetThreadListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ICMGetThreadList indicates an expected call of ICMGetThreadList.
+func (mr *MockWebServiceMockRecorder) ICMGetThreadList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ICMGetThreadList", reflect.TypeOf((*MockWebService)(nil).ICMGetThreadList), arg0, arg1)
+}
+
+// J2EEGetCacheStatistic mocks base method.
+func (m *MockWebService) J2EEGetCacheStatistic(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetCacheStatisticResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetCacheStatistic", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetCacheStatisticResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetCacheStatistic indicates an expected call of J2EEGetCacheStatistic.
+func (mr *MockWebServiceMockRecorder) J2EEGetCacheStatistic(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetCacheStatistic", reflect.TypeOf((*MockWebService)(nil).J2EEGetCacheStatistic), arg0, arg1)
+}
+
+// J2EEGetClusterMsgList mocks base method.
+func (m *MockWebService) J2EEGetClusterMsgList(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetClusterMsgListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetClusterMsgList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetClusterMsgListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetClusterMsgList indicates an expected call of J2EEGetClusterMsgList.
+func (mr *MockWebServiceMockRecorder) J2EEGetClusterMsgList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetClusterMsgList", reflect.TypeOf((*MockWebService)(nil).J2EEGetClusterMsgList), arg0, arg1)
+}
+
+// J2EEGetComponentList mocks base method.
+func (m *MockWebService) J2EEGetComponentList(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetComponentListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetComponentList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetComponentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetComponentList indicates an expected call of J2EEGetComponentList.
+func (mr *MockWebServiceMockRecorder) J2EEGetComponentList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetComponentList", reflect.TypeOf((*MockWebService)(nil).J2EEGetComponentList), arg0, arg1)
+}
+
+// J2EEGetEJBSessionList mocks base method.
+func (m *MockWebService) J2EEGetEJBSessionList(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetEJBSessionListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetEJBSessionList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetEJBSessionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetEJBSessionList indicates an expected call of J2EEGetEJBSessionList.
+func (mr *MockWebServiceMockRecorder) J2EEGetEJBSessionList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetEJBSessionList", reflect.TypeOf((*MockWebService)(nil).J2EEGetEJBSessionList), arg0, arg1)
+}
+
+// J2EEGetProcessList mocks base method.
+func (m *MockWebService) J2EEGetProcessList(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetProcessListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetProcessList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetProcessListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetProcessList indicates an expected call of J2EEGetProcessList.
+func (mr *MockWebServiceMockRecorder) J2EEGetProcessList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetProcessList", reflect.TypeOf((*MockWebService)(nil).J2EEGetProcessList), arg0, arg1)
+}
+
+// J2EEGetSessionList mocks base method.
+func (m *MockWebService) J2EEGetSessionList(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetSessionListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetSessionList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetSessionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetSessionList indicates an expected call of J2EEGetSessionList.
+func (mr *MockWebServiceMockRecorder) J2EEGetSessionList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetSessionList", reflect.TypeOf((*MockWebService)(nil).J2EEGetSessionList), arg0, arg1)
+}
+
+// J2EEGetSharedTableInfo mocks base method.
+func (m *MockWebService) J2EEGetSharedTableInfo(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetSharedTableInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetSharedTableInfo", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetSharedTableInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetSharedTableInfo indicates an expected call of J2EEGetSharedTableInfo.
+func (mr *MockWebServiceMockRecorder) J2EEGetSharedTableInfo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetSharedTableInfo", reflect.TypeOf((*MockWebService)(nil).J2EEGetSharedTableInfo), arg0, arg1)
+}
+
+// J2EEGetThreadList mocks base method.
+func (m *MockWebService) J2EEGetThreadList(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetThreadListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetThreadList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetThreadListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetThreadList indicates an expected call of J2EEGetThreadList.
+func (mr *MockWebServiceMockRecorder) J2EEGetThreadList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetThreadList", reflect.TypeOf((*MockWebService)(nil).J2EEGetThreadList), arg0, arg1)
+}
+
+// J2EEGetVMGCHistory mocks base method.
+func (m *MockWebService) J2EEGetVMGCHistory(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetVMGCHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetVMGCHistory", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetVMGCHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetVMGCHistory indicates an expected call of J2EEGetVMGCHistory.
+func (mr *MockWebServiceMockRecorder) J2EEGetVMGCHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetVMGCHistory", reflect.TypeOf((*MockWebService)(nil).J2EEGetVMGCHistory), arg0, arg1)
+}
+
+// J2EEGetVMHeapInfo mocks base method.
+func (m *MockWebService) J2EEGetVMHeapInfo(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetVMHeapInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetVMHeapInfo", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetVMHeapInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetVMHeapInfo indicates an expected call of J2EEGetVMHeapInfo.
+func (mr *MockWebServiceMockRecorder) J2EEGetVMHeapInfo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetVMHeapInfo", reflect.TypeOf((*MockWebService)(nil).J2EEGetVMHeapInfo), arg0, arg1)
+}
+
+// J2EEGetWebSessionList2 mocks base method.
+func (m *MockWebService) J2EEGetWebSessionList2(arg0 context.Context, arg1 string) (*sapcontrol.J2EEGetWebSessionList2Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "J2EEGetWebSessionList2", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.J2EEGetWebSessionList2Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// J2EEGetWebSessionList2 indicates an expected call of J2EEGetWebSessionList2.
+func (mr *MockWebServiceMockRecorder) J2EEGetWebSessionList2(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "J2EEGetWebSessionList2", reflect.TypeOf((*MockWebService)(nil).J2EEGetWebSessionList2), arg0, arg1)
 }
 
 // SetLokiClient mocks base method.
@@ -185,3 +591,18 @@ func (mr *MockWebServiceMockRecorder) SetLokiClient(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLokiClient", reflect.TypeOf((*MockWebService)(nil).SetLokiClient), arg0)
 }
+
+// WebDispGetServerList mocks base method.
+func (m *MockWebService) WebDispGetServerList(arg0 context.Context, arg1 string) (*sapcontrol.WebDispGetServerListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WebDispGetServerList", arg0, arg1)
+	ret0, _ := ret[0].(*sapcontrol.WebDispGetServerListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WebDispGetServerList indicates an expected call of WebDispGetServerList.
+func (mr *MockWebServiceMockRecorder) WebDispGetServerList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WebDispGetServerList", reflect.TypeOf((*MockWebService)(nil).WebDispGetServerList), arg0, arg1)
+}